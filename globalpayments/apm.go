@@ -0,0 +1,189 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+//Alternative payment methods accepted by Global Payments
+const (
+	APMPayPal      = "paypal"
+	APMSofort      = "sofort"
+	APMGiropay     = "giropay"
+	APMIdeal       = "ideal"
+	APMEPS         = "eps"
+	APMPostFinance = "postfinance"
+	APMTestPay     = "testpay"
+)
+
+//APMRequest request struct for alternative payment methods started with payment-set
+type APMRequest struct {
+	XMLName              xml.Name          `xml:"request"`
+	Type                 string            `xml:"type,attr"`
+	Timestamp            string            `xml:"timestamp,attr"`
+	MerchantID           string            `xml:"merchantid"`
+	Account              string            `xml:"account,omitempty"`
+	OrderID              string            `xml:"orderid"`
+	Amount               *Amount           `xml:"amount,omitempty"`
+	PaymentMethod        string            `xml:"paymentmethod"`
+	PaymentMethodDetails *APMDetails       `xml:"paymentmethoddetails,omitempty"`
+	Comments             Comments          `xml:"comments,omitempty"`
+	SupplementaryData    SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash             string            `xml:"sha1hash"`
+	serviceAuthenticator
+}
+
+//APMDetails request struct. The customer is sent back to ReturnURL once they leave the payment method's site, and the
+//result of the payment is posted to StatusUpdateURL once it is known.
+type APMDetails struct {
+	ReturnURL         string `xml:"ReturnURL"`
+	StatusUpdateURL   string `xml:"StatusUpdateURL"`
+	CancelURL         string `xml:"CancelURL,omitempty"`
+	Descriptor        string `xml:"Descriptor,omitempty"`
+	Country           string `xml:"Country,omitempty"`
+	AccountHolderName string `xml:"AccountHolderName,omitempty"`
+}
+
+//APMResponse response struct for payment-set
+type APMResponse struct {
+	XMLName xml.Name `xml:"response"`
+	ServiceResponse
+	PaymentMethodDetails *APMResult `xml:"paymentmethoddetails"`
+}
+
+//APMResult response struct
+type APMResult struct {
+	RedirectURL   string `xml:"redirecturl"`
+	PaymentMethod string `xml:"paymentmethod"`
+	Token         string `xml:"token"`
+}
+
+//RedirectURL returns the URL the customer is redirected to to complete the payment
+func (response *APMResponse) RedirectURL() string {
+	if response.PaymentMethodDetails == nil {
+		return ""
+	}
+	return response.PaymentMethodDetails.RedirectURL
+}
+
+//APMStatusUpdate notification posted to the StatusUpdateURL once the result of an alternative payment is known
+type APMStatusUpdate struct {
+	Timestamp     string
+	MerchantID    string
+	Account       string
+	OrderID       string
+	Result        string
+	Message       string
+	PasRef        string
+	PaymentMethod string
+	Sha1Hash      string
+	serviceAuthenticator
+}
+
+//APMValidationError for status update notifications whose sha1hash does not match, for example because they were not
+//posted by Global Payments
+type APMValidationError struct {
+	OrderID string
+}
+
+func (err *APMValidationError) Error() string {
+	return fmt.Sprintf("APM Validation Hash Error: order id: %v", err.OrderID)
+}
+
+//Successful reports whether the payment completed
+func (update *APMStatusUpdate) Successful() bool {
+	return ResultCode(update.Result).IsApproved()
+}
+
+func (update *APMStatusUpdate) validateStatusUpdateHash() (err error) {
+	signature, err := update.buildSignature()
+	if err != nil {
+		return err
+	}
+	if signature == update.Sha1Hash {
+		return nil
+	}
+	return &APMValidationError{update.OrderID}
+}
+
+//APMService Alternative Payment Methods API offers payments such as PayPal and bank redirects, where the customer is sent to
+//the payment method's site and the result is posted back asynchronously.
+type APMService struct {
+	service
+}
+
+//APMServiceAPI interface contain all request types that are allowed within this service for mocking on upstream consumers
+type APMServiceAPI interface {
+	Start(request *APMRequest) (*APMResponse, *http.Response,
+		error)
+	ParseStatusUpdate(r *http.Request) (*APMStatusUpdate, error)
+}
+
+//used getters for objects used within the hash
+
+func (request APMRequest) getAmount() string {
+	if request.Amount != nil {
+		return request.Amount.Amount
+	}
+	return ""
+}
+
+func (request APMRequest) getCurrency() string {
+	if request.Amount != nil {
+		return request.Amount.Currency
+	}
+	return ""
+}
+
+//Start starts an alternative payment. The customer is redirected to the returned RedirectURL and the result is posted to
+//the StatusUpdateURL, where it is read with ParseStatusUpdate.
+func (apm *APMService) Start(request *APMRequest) (*APMResponse, *http.Response,
+	error) {
+	if err := request.validate(); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = apm.client.MerchantID
+	request.Type = requestTypeAPMPaymentSet
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PaymentMethod}
+	request.sharedSecret = apm.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+
+	response := &APMResponse{}
+	httpResponse, err := apm.transmit(request, response)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	return response, httpResponse, nil
+}
+
+//ParseStatusUpdate reads the status update notification posted to the StatusUpdateURL and verifies its sha1hash
+func (apm *APMService) ParseStatusUpdate(r *http.Request) (*APMStatusUpdate, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	update := &APMStatusUpdate{
+		Timestamp:     r.Form.Get("timestamp"),
+		MerchantID:    r.Form.Get("merchantid"),
+		Account:       r.Form.Get("account"),
+		OrderID:       r.Form.Get("orderid"),
+		Result:        r.Form.Get("result"),
+		Message:       r.Form.Get("message"),
+		PasRef:        r.Form.Get("pasref"),
+		PaymentMethod: r.Form.Get("paymentmethod"),
+		Sha1Hash:      r.Form.Get("sha1hash"),
+	}
+	update.elementsToHash = []string{update.Timestamp, update.MerchantID, update.OrderID, update.Result, update.Message, update.PasRef, update.PaymentMethod}
+	update.sharedSecret = apm.client.HashSecret
+	err = update.validateStatusUpdateHash()
+	if err != nil {
+		return nil, err
+	}
+	return update, nil
+}
@@ -0,0 +1,100 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAPMService_Start(t *testing.T) {
+	apmRequest := &APMRequest{
+		Account: "internet",
+		OrderID: "N6qsk4kYRZihmPrTXWYS6g",
+		Amount: &Amount{
+			Amount:   "1001",
+			Currency: "EUR",
+		},
+		PaymentMethod: APMPayPal,
+		PaymentMethodDetails: &APMDetails{
+			ReturnURL:       "https://merchant.example.com/apm/return",
+			StatusUpdateURL: "https://merchant.example.com/apm/status",
+			CancelURL:       "https://merchant.example.com/apm/cancel",
+		},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="payment-set" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>N6qsk4kYRZihmPrTXWYS6g</orderid><amount currency="EUR">1001</amount><paymentmethod>paypal</paymentmethod><paymentmethoddetails><ReturnURL>https://merchant.example.com/apm/return</ReturnURL><StatusUpdateURL>https://merchant.example.com/apm/status</StatusUpdateURL><CancelURL>https://merchant.example.com/apm/cancel</CancelURL></paymentmethoddetails><sha1hash>cd831a3eb66e4e51b90970fea86b0f864d28b82a</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <result>01</result>
+							   <message>Pending</message>
+							   <pasref>14610544313177922</pasref>
+							   <paymentmethoddetails>
+								  <redirecturl>https://www.sandbox.paypal.com/cgi-bin/webscr?cmd=_express-checkout&amp;token=EC-0M812138CK0658439</redirecturl>
+								  <paymentmethod>paypal</paymentmethod>
+								  <token>EC-0M812138CK0658439</token>
+							   </paymentmethoddetails>
+							   <sha1hash>1dbaea015d7ffbba9388c06cbf7b837b2f4852d4</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.APM.Start(apmRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if got, want := response.RedirectURL(), "https://www.sandbox.paypal.com/cgi-bin/webscr?cmd=_express-checkout&token=EC-0M812138CK0658439"; got != want {
+		t.Errorf("Response RedirectURL = %v, want %v", got, want)
+	}
+}
+
+func TestAPMService_ParseStatusUpdate(t *testing.T) {
+	client, _ := NewClient()
+
+	form := url.Values{
+		"timestamp":     {"20180614101500"},
+		"merchantid":    {"realexsandbox"},
+		"account":       {"internet"},
+		"orderid":       {"N6qsk4kYRZihmPrTXWYS6g"},
+		"result":        {"00"},
+		"message":       {"[ test system ] AUTHORISED"},
+		"pasref":        {"14610544313177922"},
+		"paymentmethod": {"paypal"},
+		"sha1hash":      {"7a87f1180af7a790eff09888129531e2eb0c78f3"},
+	}
+	request := httptest.NewRequest("POST", "/apm/status", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	update, err := client.APM.ParseStatusUpdate(request)
+	if err != nil {
+		t.Errorf("Error parsing APM status update: %v", err)
+	}
+
+	if !update.Successful() {
+		t.Errorf("APM status update Successful = false for result %v", update.Result)
+	}
+
+	form.Set("result", "101")
+	request = httptest.NewRequest("POST", "/apm/status?"+form.Encode(), nil)
+	_, err = client.APM.ParseStatusUpdate(request)
+	if _, ok := err.(*APMValidationError); !ok {
+		t.Errorf("APM status update error = %v, want *APMValidationError", err)
+	}
+}
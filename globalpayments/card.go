@@ -0,0 +1,129 @@
+package globalpayments
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//iinRange inclusive range of issuer identification number prefixes, compared on the first len(low) digits of the number
+type iinRange struct {
+	low  string
+	high string
+}
+
+//cardScheme IIN ranges, card number lengths and CVN length of a card type
+type cardScheme struct {
+	cardType  string
+	ranges    []iinRange
+	lengths   []int
+	cvnLength int
+}
+
+//cardSchemes card types in the order they are detected, narrower ranges come before the ranges they overlap
+var cardSchemes = []cardScheme{
+	{CardTypeAmex, []iinRange{{"34", "34"}, {"37", "37"}}, []int{15}, 4},
+	{CardTypeDiners, []iinRange{{"300", "305"}, {"3095", "3095"}, {"36", "36"}, {"38", "39"}}, []int{14, 15, 16, 17, 18, 19}, 3},
+	{CardTypeJCB, []iinRange{{"3528", "3589"}}, []int{16, 17, 18, 19}, 3},
+	{CardTypeDiscover, []iinRange{{"6011", "6011"}, {"622126", "622925"}, {"644", "649"}, {"65", "65"}}, []int{16, 17, 18, 19}, 3},
+	{CardTypeMastercard, []iinRange{{"51", "55"}, {"2221", "2720"}}, []int{16}, 3},
+	{CardTypeVisa, []iinRange{{"4", "4"}}, []int{13, 16, 19}, 3},
+}
+
+func findCardScheme(cardType string) *cardScheme {
+	for i := range cardSchemes {
+		if cardSchemes[i].cardType == cardType {
+			return &cardSchemes[i]
+		}
+	}
+	return nil
+}
+
+//DetectCardType returns the card type of a card number from its IIN, or an empty string if it is not recognised
+func DetectCardType(number string) string {
+	for _, scheme := range cardSchemes {
+		for _, iin := range scheme.ranges {
+			if len(number) < len(iin.low) {
+				continue
+			}
+			prefix := number[:len(iin.low)]
+			if prefix >= iin.low && prefix <= iin.high {
+				return scheme.cardType
+			}
+		}
+	}
+	return ""
+}
+
+//luhnValid reports whether the card number passes the Luhn check
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+//ParseExpDate parses an MMYY expiry date and returns the last moment the card is valid, the end of its expiry month
+func ParseExpDate(expDate string) (time.Time, error) {
+	if len(expDate) != 4 || onlyDigits(expDate) != expDate {
+		return time.Time{}, &FieldError{Field: "card.expdate", Message: fmt.Sprintf("%v is not an MMYY date", expDate)}
+	}
+	month, _ := strconv.Atoi(expDate[:2])
+	year, _ := strconv.Atoi(expDate[2:])
+	if month < 1 || month > 12 {
+		return time.Time{}, &FieldError{Field: "card.expdate", Message: fmt.Sprintf("%v is not an MMYY date", expDate)}
+	}
+	return time.Date(2000+year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), nil
+}
+
+//Validate checks the card number, expiry date and CVN before they are sent to Global Payments, filling in Type from the
+//card number when it is empty
+func (card *Card) Validate() error {
+	if card.Number == "" || onlyDigits(card.Number) != card.Number {
+		return &FieldError{Field: "card.number", Message: "must contain only digits"}
+	}
+	if !luhnValid(card.Number) {
+		return &FieldError{Field: "card.number", Message: "fails the Luhn check"}
+	}
+	if card.Type == "" {
+		card.Type = DetectCardType(card.Number)
+	}
+	if scheme := findCardScheme(card.Type); scheme != nil {
+		if !containsLength(scheme.lengths, len(card.Number)) {
+			return &FieldError{Field: "card.number", Message: fmt.Sprintf("%d digits is not a valid length for %v", len(card.Number), card.Type)}
+		}
+		if card.CVN != nil && card.CVN.Number != "" && len(card.CVN.Number) != scheme.cvnLength {
+			return &FieldError{Field: "card.cvn.number", Message: fmt.Sprintf("must be %d digits for %v", scheme.cvnLength, card.Type)}
+		}
+	}
+	if card.CVN != nil && onlyDigits(card.CVN.Number) != card.CVN.Number {
+		return &FieldError{Field: "card.cvn.number", Message: "must contain only digits"}
+	}
+	expires, err := ParseExpDate(card.ExpDate)
+	if err != nil {
+		return err
+	}
+	if Now().After(expires) {
+		return &FieldError{Field: "card.expdate", Message: fmt.Sprintf("card expired %v", card.ExpDate)}
+	}
+	return nil
+}
+
+func containsLength(lengths []int, length int) bool {
+	for _, l := range lengths {
+		if l == length {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package globalpayments
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectCardType(t *testing.T) {
+	for number, want := range map[string]string{
+		"4263970000005262": CardTypeVisa,
+		"5425230000004415": CardTypeMastercard,
+		"2223000010005780": CardTypeMastercard,
+		"374101000000608":  CardTypeAmex,
+		"36256000000725":   CardTypeDiners,
+		"3566000020000410": CardTypeJCB,
+		"6011000990156527": CardTypeDiscover,
+		"9999000000000000": "",
+	} {
+		if got := DetectCardType(number); got != want {
+			t.Errorf("DetectCardType(%v) = %v, want %v", number, got, want)
+		}
+	}
+}
+
+func TestParseExpDate(t *testing.T) {
+	expires, err := ParseExpDate("0219")
+	if err != nil {
+		t.Errorf("Error parsing expiry date: %v", err)
+	}
+	if want := time.Date(2019, time.February, 28, 23, 59, 59, 999999999, time.UTC); !expires.Equal(want) {
+		t.Errorf("ParseExpDate(0219) = %v, want %v", expires, want)
+	}
+
+	for _, expDate := range []string{"1319", "219", "02/19", "ab19"} {
+		if _, err := ParseExpDate(expDate); err == nil {
+			t.Errorf("ParseExpDate(%v) returned no error", expDate)
+		}
+	}
+}
+
+func TestCard_Validate(t *testing.T) {
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+
+	card := &Card{Number: "4263970000005262", ExpDate: "0618", CVN: &CVN{Number: "123"}}
+	if err := card.Validate(); err != nil {
+		t.Errorf("Error validating card: %v", err)
+	}
+	if got, want := card.Type, CardTypeVisa; got != want {
+		t.Errorf("Card Type = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		card  *Card
+		field string
+	}{
+		{&Card{Number: "4263 9700 0000 5262", ExpDate: "0525"}, "card.number"},
+		{&Card{Number: "4263970000005263", ExpDate: "0525"}, "card.number"},
+		{&Card{Number: "42639700000052", ExpDate: "0525"}, "card.number"},
+		{&Card{Number: "374101000000608", ExpDate: "0525", CVN: &CVN{Number: "123"}}, "card.cvn.number"},
+		{&Card{Number: "4263970000005262", ExpDate: "0525", CVN: &CVN{Number: "1234"}}, "card.cvn.number"},
+		{&Card{Number: "4263970000005262", ExpDate: "0518"}, "card.expdate"},
+		{&Card{Number: "4263970000005262", ExpDate: "1325"}, "card.expdate"},
+	}
+	for _, test := range tests {
+		err := test.card.Validate()
+		fieldError, ok := err.(*FieldError)
+		if !ok || fieldError.Field != test.field {
+			t.Errorf("Card %v Validate error = %v, want field %v", test.card.Number, err, test.field)
+		}
+	}
+}
+
+func TestCardStorageService_StoreCard_Validation(t *testing.T) {
+	client, _ := NewClient()
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+
+	_, _, err := client.CardStorage.StoreCard(&CardStorageRequest{Card: &Card{Ref: "3c4af936-483e-a393-f558bec2fb2a", PayerRef: "0f357b45-9aa4-4453-a685-c69232e9024f", Number: "4263970000005263", ExpDate: "0525", CardHolderName: "James Mason"}})
+	if got := strings.Join(fieldErrorFields(err), ","); got != "card.number" {
+		t.Errorf("StoreCard error = %v, want a card.number field error", err)
+	}
+}
@@ -8,21 +8,29 @@ import (
 
 //CardStorageRequest request struct for all apis
 type CardStorageRequest struct {
-	XMLName       xml.Name     `xml:"request"`
-	Type          string       `xml:"type,attr"`
-	Timestamp     string       `xml:"timestamp,attr"`
-	MerchantID    string       `xml:"merchantid"`
-	Account       string       `xml:"account,omitempty"`
-	Channel       string       `xml:"channel,omitempty"`
-	OrderID       string       `xml:"orderid"`
-	PayerRef      string       `xml:"payerref"`
-	PaymentMethod string       `xml:"paymentmethod,omitempty"`
-	Sha1Hash      string       `xml:"sha1hash"`
-	Amount        *Amount      `xml:"amount,omitempty"`
-	AutoSettle    *AutoSettle  `xml:"autosettle,omitempty"`
-	PaymentData   *PaymentData `xml:"paymentdata,omitempty"`
-	Payer         *Payer       `xml:"payer,omitempty"`
-	Card          *Card        `xml:"card,omitempty"`
+	XMLName           xml.Name          `xml:"request"`
+	Type              string            `xml:"type,attr"`
+	Timestamp         string            `xml:"timestamp,attr"`
+	MerchantID        string            `xml:"merchantid"`
+	Account           string            `xml:"account,omitempty"`
+	Channel           Channel           `xml:"channel,omitempty"`
+	OrderID           string            `xml:"orderid"`
+	PayerRef          string            `xml:"payerref"`
+	PaymentMethod     string            `xml:"paymentmethod,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+	Amount            *Amount           `xml:"amount,omitempty"`
+	AutoSettle        *AutoSettle       `xml:"autosettle,omitempty"`
+	PaymentData       *PaymentData      `xml:"paymentdata,omitempty"`
+	Payer             *Payer            `xml:"payer,omitempty"`
+	Card              *Card             `xml:"card,omitempty"`
+	StoredCredential  *StoredCredential `xml:"storedcredential,omitempty"`
+	Recurring         *Recurring        `xml:"recurring,omitempty"`
+	DCCInfo           *DCCInfo          `xml:"dccinfo,omitempty"`
+	MPI               *MPI              `xml:"mpi,omitempty"`
+	FraudFilter       *FraudFilter      `xml:"fraudfilter,omitempty"`
+	TSSInfo           *TSSInfo          `xml:"tssinfo,omitempty"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
 	serviceAuthenticator
 }
 
@@ -44,7 +52,8 @@ type PaymentData struct {
 
 //CVN request struct
 type CVN struct {
-	Number string `xml:"number"`
+	Number  string `xml:"number"`
+	PresInd string `xml:"presind,omitempty"`
 }
 
 //Payer request struct
@@ -92,14 +101,25 @@ type Country struct {
 
 //Card request struct
 type Card struct {
-	Ref            string `xml:"ref"`
-	PayerRef       string `xml:"payerref"`
+	Ref            string `xml:"ref,omitempty"`
+	PayerRef       string `xml:"payerref,omitempty"`
 	Number         string `xml:"number"`
 	ExpDate        string `xml:"expdate"`
 	CardHolderName string `xml:"chname"`
 	Type           string `xml:"type"`
+	CVN            *CVN   `xml:"cvn,omitempty"`
 }
 
+//Card types accepted by Global Payments
+const (
+	CardTypeVisa       = "VISA"
+	CardTypeMastercard = "MC"
+	CardTypeAmex       = "AMEX"
+	CardTypeDiners     = "DINERS"
+	CardTypeJCB        = "JCB"
+	CardTypeDiscover   = "DISCOVER"
+)
+
 //CardStorageService  Card Storage API offers a range of easy-to-use requests to store, charge, update and delete cards.
 type CardStorageService struct {
 	service
@@ -123,6 +143,12 @@ type CardStorageServiceAPI interface {
 		error)
 	DeleteCard(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 		error)
+	DCCRate(request *CardStorageRequest) (*ServiceResponse, *http.Response,
+		error)
+	VerifyEnrolled(request *CardStorageRequest) (*ThreeDSecureResponse, *http.Response,
+		error)
+	Send(request CardStorageOperation) (*ServiceResponse, *http.Response,
+		error)
 }
 
 //TimeFormatter interface
@@ -193,101 +219,164 @@ func (request CardStorageRequest) getCardExpDate() string {
 //card data from our vault and builds an authorization which we then send on to the Issuer.
 func (cardStorage *CardStorageService) Authorize(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signAuthorize(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signAuthorize(request *CardStorageRequest) error {
+	request.applyChannel(cardStorage.client)
+	if err := request.validate(requestTypeReceiptIn); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "receipt-in"
-	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.Amount.Amount, request.Amount.Currency, request.PayerRef}
+	request.Type = requestTypeReceiptIn
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PayerRef}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //Validate Open to Buy (OTB) allows you to check that a stored card is still valid and active without actually processing a payment
 //against it. This is an alternative to charging the card a small amount (for example 10c) to obtain the same result.
 func (cardStorage *CardStorageService) Validate(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signValidate(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signValidate(request *CardStorageRequest) error {
+	request.applyChannel(cardStorage.client)
+	if err := request.validate(requestTypeReceiptInOTB); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "receipt-in-otb"
+	request.Type = requestTypeReceiptInOTB
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.PayerRef}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //Credit request type allows you to credit an amount to a stored card.
 func (cardStorage *CardStorageService) Credit(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signCredit(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signCredit(request *CardStorageRequest) error {
+	request.applyChannel(cardStorage.client)
+	if err := request.validate(requestTypePaymentOut); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "payment-out"
+	request.Type = requestTypePaymentOut
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PayerRef}
 	request.sharedSecret = cardStorage.client.RebateHashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //CreateCustomer In order to store a card, the first thing we need to do is set up a customer reference (Payer). You can also choose to
 //store address and contact details alongside it.
 func (cardStorage *CardStorageService) CreateCustomer(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signCreateCustomer(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signCreateCustomer(request *CardStorageRequest) error {
+	if err := request.validate(requestTypePayerNew); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "payer-new"
+	request.Type = requestTypePayerNew
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getPayerRef()}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //EditCustomer Once a customer has been created you can update their name, address or contact details which can be viewed in Ecommerce Portal.
 func (cardStorage *CardStorageService) EditCustomer(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signEditCustomer(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signEditCustomer(request *CardStorageRequest) error {
+	if err := request.validate(requestTypePayerEdit); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "payer-edit"
+	request.Type = requestTypePayerEdit
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PayerRef}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //StoreCard Once we have our customer entity created, we can now add cards to it. This request must contain the card data to be stored,
 //a unique reference for it and the customer reference it is to be added to. We'd always recommend processing an authorization
-//against a card or validating it (OTB) before adding it.
+//against a card or validating it (OTB) before adding it. The card is checked with Card.Validate before it is sent.
 func (cardStorage *CardStorageService) StoreCard(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signStoreCard(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signStoreCard(request *CardStorageRequest) error {
+	if err := request.validate(requestTypeCardNew); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "card-new"
+	request.Type = requestTypeCardNew
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PayerRef, request.getCardHolderName(), request.getCardNumber()}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //EditCard If your customer's card details change, for example if the expiry date is updated or they get a new card number, you can
@@ -295,31 +384,51 @@ func (cardStorage *CardStorageService) StoreCard(request *CardStorageRequest) (*
 //bits of data, for example just the expiry date. In the example below we are completely replacing the card with a new one.
 func (cardStorage *CardStorageService) EditCard(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signEditCard(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signEditCard(request *CardStorageRequest) error {
+	if err := request.validate(requestTypeCardUpdate); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "card-update-card"
+	request.Type = requestTypeCardUpdate
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.PayerRef, request.getCardRef(), request.getCardExpDate(), request.getCardNumber()}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
 
 //DeleteCard If you want to remove a card from Card Storage you can send us a Card Delete request.
 func (cardStorage *CardStorageService) DeleteCard(request *CardStorageRequest) (*ServiceResponse, *http.Response,
 	error) {
+	if err := cardStorage.signDeleteCard(request); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
+
+func (cardStorage *CardStorageService) signDeleteCard(request *CardStorageRequest) error {
+	if err := request.validate(requestTypeCardCancel); err != nil {
+		return err
+	}
 	request.Timestamp = formatTime(Now(), "20060102150405")
 	request.MerchantID = cardStorage.client.MerchantID
-	request.Type = "card-cancel-card"
+	request.Type = requestTypeCardCancel
 	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.PayerRef, request.getCardRef()}
 	request.sharedSecret = cardStorage.client.HashSecret
 	signature, err := request.buildSignature()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	request.Sha1Hash = signature
-	return cardStorage.transmitRequest(request)
+	return nil
 }
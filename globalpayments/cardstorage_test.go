@@ -54,6 +54,14 @@ func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown fun
 	return client, mux, server.URL, server.Close
 }
 
+//fixNow fixes Now at the request timestamp used by the tests, 20180614095000, so the 0525 fixture cards have not
+//expired, and restores it when the test ends
+func fixNow(t *testing.T) {
+	now := Now
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+	t.Cleanup(func() { Now = now })
+}
+
 func TestCardStorageService_Authorize(t *testing.T) {
 
 	authRequest := &CardStorageRequest{
@@ -128,6 +136,7 @@ func TestCardStorageService_Authorize(t *testing.T) {
 		BatchID:             "319623",
 		TimeTaken:           "1",
 		AuthTimeTaken:       "0",
+		SRD:                 "MMC0F00YE4000000715",
 		CardIssuer: &CardIssuer{
 			Bank:        "AIB BANK",
 			Country:     "IRELAND",
@@ -208,6 +217,7 @@ func TestCardStorageService_Validate(t *testing.T) {
 		BatchID:             "319623",
 		TimeTaken:           "1",
 		AuthTimeTaken:       "0",
+		SRD:                 "MMC0F00YE4000000715",
 		CardIssuer: &CardIssuer{
 			Bank:        "AIB BANK",
 			Country:     "IRELAND",
@@ -292,6 +302,7 @@ func TestCardStorageService_Credit(t *testing.T) {
 		BatchID:             "319623",
 		TimeTaken:           "1",
 		AuthTimeTaken:       "0",
+		SRD:                 "MMC0F00YE4000000715",
 		CardIssuer: &CardIssuer{
 			Bank:        "AIB BANK",
 			Country:     "IRELAND",
@@ -408,6 +419,7 @@ func TestCardStorageService_CreateCustomer(t *testing.T) {
 		BatchID:             "319623",
 		TimeTaken:           "1",
 		AuthTimeTaken:       "0",
+		SRD:                 "MMC0F00YE4000000715",
 		CardIssuer: &CardIssuer{
 			Bank:        "AIB BANK",
 			Country:     "IRELAND",
@@ -523,6 +535,7 @@ func TestCardStorageService_EditCustomer(t *testing.T) {
 		BatchID:             "319623",
 		TimeTaken:           "1",
 		AuthTimeTaken:       "0",
+		SRD:                 "MMC0F00YE4000000715",
 		CardIssuer: &CardIssuer{
 			Bank:        "AIB BANK",
 			Country:     "IRELAND",
@@ -0,0 +1,488 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+//CardStorageOperation typed request for a single Card Storage operation, built with one of the New...Request constructors
+//and sent with CardStorageService.Send. Only the elements accepted by the operation are sent.
+type CardStorageOperation interface {
+	sign(cardStorage *CardStorageService) error
+}
+
+//requestHeader request attributes and elements shared by the typed Card Storage requests. Type, timestamp and merchant
+//are set when the request is signed.
+type requestHeader struct {
+	XMLName    xml.Name `xml:"request"`
+	Type       string   `xml:"type,attr"`
+	Timestamp  string   `xml:"timestamp,attr"`
+	MerchantID string   `xml:"merchantid"`
+	Account    string   `xml:"account,omitempty"`
+}
+
+//signWith signs request with the same checks and hash as the CardStorageService method for the operation and returns
+//the signature
+func (header *requestHeader) signWith(sign func(request *CardStorageRequest) error, request *CardStorageRequest) (string, error) {
+	request.Account = header.Account
+	if err := sign(request); err != nil {
+		return "", err
+	}
+	header.Type, header.Timestamp, header.MerchantID = request.Type, request.Timestamp, request.MerchantID
+	return request.Sha1Hash, nil
+}
+
+//AuthorizeRequest receipt-in request raising an authorization against a stored card
+type AuthorizeRequest struct {
+	requestHeader
+	Channel           Channel           `xml:"channel,omitempty"`
+	OrderID           string            `xml:"orderid"`
+	PayerRef          string            `xml:"payerref"`
+	PaymentMethod     string            `xml:"paymentmethod"`
+	Amount            *Amount           `xml:"amount"`
+	AutoSettle        *AutoSettle       `xml:"autosettle,omitempty"`
+	PaymentData       *PaymentData      `xml:"paymentdata,omitempty"`
+	StoredCredential  *StoredCredential `xml:"storedcredential,omitempty"`
+	Recurring         *Recurring        `xml:"recurring,omitempty"`
+	DCCInfo           *DCCInfo          `xml:"dccinfo,omitempty"`
+	MPI               *MPI              `xml:"mpi,omitempty"`
+	FraudFilter       *FraudFilter      `xml:"fraudfilter,omitempty"`
+	TSSInfo           *TSSInfo          `xml:"tssinfo,omitempty"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewAuthorizeRequest authorization of amount against the card paymentMethod stored for payerRef
+func NewAuthorizeRequest(orderID string, payerRef string, paymentMethod string, amount *Amount) *AuthorizeRequest {
+	return &AuthorizeRequest{OrderID: orderID, PayerRef: payerRef, PaymentMethod: paymentMethod, Amount: amount}
+}
+
+func (request *AuthorizeRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		Channel:           request.Channel,
+		OrderID:           request.OrderID,
+		PayerRef:          request.PayerRef,
+		PaymentMethod:     request.PaymentMethod,
+		Amount:            request.Amount,
+		AutoSettle:        request.AutoSettle,
+		PaymentData:       request.PaymentData,
+		StoredCredential:  request.StoredCredential,
+		Recurring:         request.Recurring,
+		DCCInfo:           request.DCCInfo,
+		MPI:               request.MPI,
+		FraudFilter:       request.FraudFilter,
+		TSSInfo:           request.TSSInfo,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signAuthorize, generic)
+	request.Channel = generic.Channel
+	return err
+}
+
+//ValidateRequest receipt-in-otb request checking a stored card is valid without charging it
+type ValidateRequest struct {
+	requestHeader
+	Channel           Channel           `xml:"channel,omitempty"`
+	OrderID           string            `xml:"orderid"`
+	PayerRef          string            `xml:"payerref"`
+	PaymentMethod     string            `xml:"paymentmethod"`
+	PaymentData       *PaymentData      `xml:"paymentdata,omitempty"`
+	StoredCredential  *StoredCredential `xml:"storedcredential,omitempty"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewValidateRequest Open to Buy check of the card paymentMethod stored for payerRef
+func NewValidateRequest(orderID string, payerRef string, paymentMethod string) *ValidateRequest {
+	return &ValidateRequest{OrderID: orderID, PayerRef: payerRef, PaymentMethod: paymentMethod}
+}
+
+func (request *ValidateRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		Channel:           request.Channel,
+		OrderID:           request.OrderID,
+		PayerRef:          request.PayerRef,
+		PaymentMethod:     request.PaymentMethod,
+		PaymentData:       request.PaymentData,
+		StoredCredential:  request.StoredCredential,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signValidate, generic)
+	request.Channel = generic.Channel
+	return err
+}
+
+//CreditRequest payment-out request crediting an amount to a stored card
+type CreditRequest struct {
+	requestHeader
+	Channel           Channel           `xml:"channel,omitempty"`
+	OrderID           string            `xml:"orderid"`
+	PayerRef          string            `xml:"payerref"`
+	PaymentMethod     string            `xml:"paymentmethod"`
+	Amount            *Amount           `xml:"amount"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewCreditRequest credit of amount to the card paymentMethod stored for payerRef
+func NewCreditRequest(orderID string, payerRef string, paymentMethod string, amount *Amount) *CreditRequest {
+	return &CreditRequest{OrderID: orderID, PayerRef: payerRef, PaymentMethod: paymentMethod, Amount: amount}
+}
+
+func (request *CreditRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		Channel:           request.Channel,
+		OrderID:           request.OrderID,
+		PayerRef:          request.PayerRef,
+		PaymentMethod:     request.PaymentMethod,
+		Amount:            request.Amount,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signCredit, generic)
+	request.Channel = generic.Channel
+	return err
+}
+
+//CreateCustomerRequest payer-new request setting up a customer reference. Payer fields left empty are not sent.
+type CreateCustomerRequest struct {
+	requestHeader
+	OrderID           string            `xml:"orderid,omitempty"`
+	Payer             *Payer            `xml:"-"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewCreateCustomerRequest creates the customer payer, identified by payer.Ref
+func NewCreateCustomerRequest(orderID string, payer *Payer) *CreateCustomerRequest {
+	return &CreateCustomerRequest{OrderID: orderID, Payer: payer}
+}
+
+func (request *CreateCustomerRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		OrderID:           request.OrderID,
+		Payer:             request.Payer,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signCreateCustomer, generic)
+	return err
+}
+
+//MarshalXML writes the payer without its empty elements
+func (request *CreateCustomerRequest) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	type createCustomerRequest CreateCustomerRequest
+	return encoder.Encode(struct {
+		*createCustomerRequest
+		Payer *payerElement `xml:"payer"`
+	}{(*createCustomerRequest)(request), newPayerElement(request.Payer)})
+}
+
+//EditCustomerRequest payer-edit request updating a customer's name, address or contact details. Payer fields left
+//empty are not sent.
+type EditCustomerRequest struct {
+	requestHeader
+	OrderID           string            `xml:"orderid,omitempty"`
+	Payer             *Payer            `xml:"-"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewEditCustomerRequest updates the customer identified by payer.Ref
+func NewEditCustomerRequest(orderID string, payer *Payer) *EditCustomerRequest {
+	return &EditCustomerRequest{OrderID: orderID, Payer: payer}
+}
+
+func (request *EditCustomerRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		OrderID:           request.OrderID,
+		PayerRef:          request.getPayerRef(),
+		Payer:             request.Payer,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signEditCustomer, generic)
+	return err
+}
+
+func (request *EditCustomerRequest) getPayerRef() string {
+	if request.Payer != nil {
+		return request.Payer.Ref
+	}
+	return ""
+}
+
+//MarshalXML writes the payer without its empty elements
+func (request *EditCustomerRequest) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	type editCustomerRequest EditCustomerRequest
+	return encoder.Encode(struct {
+		*editCustomerRequest
+		Payer *payerElement `xml:"payer"`
+	}{(*editCustomerRequest)(request), newPayerElement(request.Payer)})
+}
+
+//StoreCardRequest card-new request adding a card to a customer. The CVN is never stored, so it is not sent.
+type StoreCardRequest struct {
+	requestHeader
+	OrderID           string            `xml:"orderid,omitempty"`
+	Card              *Card             `xml:"-"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewStoreCardRequest stores a copy of card against payerRef as cardRef
+func NewStoreCardRequest(orderID string, payerRef string, cardRef string, card *Card) *StoreCardRequest {
+	return &StoreCardRequest{OrderID: orderID, Card: storedCardCopy(payerRef, cardRef, card)}
+}
+
+func (request *StoreCardRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		OrderID:           request.OrderID,
+		PayerRef:          request.getPayerRef(),
+		Card:              request.Card,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signStoreCard, generic)
+	return err
+}
+
+func (request *StoreCardRequest) getPayerRef() string {
+	if request.Card != nil {
+		return request.Card.PayerRef
+	}
+	return ""
+}
+
+//MarshalXML writes the card with only the elements card-new accepts
+func (request *StoreCardRequest) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.Encode(struct {
+		requestHeader
+		OrderID           string            `xml:"orderid,omitempty"`
+		Card              *storeCardElement `xml:"card"`
+		Comments          Comments          `xml:"comments,omitempty"`
+		SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+		Sha1Hash          string            `xml:"sha1hash"`
+	}{request.requestHeader, request.OrderID, newStoreCardElement(request.Card), request.Comments, request.SupplementaryData, request.Sha1Hash})
+}
+
+//EditCardRequest card-update-card request replacing the details of a stored card. Card fields left empty are not sent
+//and keep their stored value.
+type EditCardRequest struct {
+	requestHeader
+	Card              *Card             `xml:"-"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewEditCardRequest replaces the card stored against payerRef as cardRef with a copy of card
+func NewEditCardRequest(payerRef string, cardRef string, card *Card) *EditCardRequest {
+	return &EditCardRequest{Card: storedCardCopy(payerRef, cardRef, card)}
+}
+
+func (request *EditCardRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		PayerRef:          request.getPayerRef(),
+		Card:              request.Card,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signEditCard, generic)
+	return err
+}
+
+func (request *EditCardRequest) getPayerRef() string {
+	if request.Card != nil {
+		return request.Card.PayerRef
+	}
+	return ""
+}
+
+//MarshalXML writes the card with only the elements card-update-card accepts, leaving out the empty ones
+func (request *EditCardRequest) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.Encode(struct {
+		requestHeader
+		Card              *editCardElement  `xml:"card"`
+		Comments          Comments          `xml:"comments,omitempty"`
+		SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+		Sha1Hash          string            `xml:"sha1hash"`
+	}{request.requestHeader, newEditCardElement(request.Card), request.Comments, request.SupplementaryData, request.Sha1Hash})
+}
+
+//DeleteCardRequest card-cancel-card request removing a stored card. Only the card and payer references are sent.
+type DeleteCardRequest struct {
+	requestHeader
+	Card              *Card             `xml:"-"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+}
+
+//NewDeleteCardRequest removes the card stored against payerRef as cardRef
+func NewDeleteCardRequest(payerRef string, cardRef string) *DeleteCardRequest {
+	return &DeleteCardRequest{Card: &Card{Ref: cardRef, PayerRef: payerRef}}
+}
+
+func (request *DeleteCardRequest) sign(cardStorage *CardStorageService) (err error) {
+	generic := &CardStorageRequest{
+		PayerRef:          request.getPayerRef(),
+		Card:              request.Card,
+		Comments:          request.Comments,
+		SupplementaryData: request.SupplementaryData,
+	}
+	request.Sha1Hash, err = request.signWith(cardStorage.signDeleteCard, generic)
+	return err
+}
+
+func (request *DeleteCardRequest) getPayerRef() string {
+	if request.Card != nil {
+		return request.Card.PayerRef
+	}
+	return ""
+}
+
+//MarshalXML writes only the card and payer references
+func (request *DeleteCardRequest) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encoder.Encode(struct {
+		requestHeader
+		Card              *deleteCardElement `xml:"card"`
+		Comments          Comments           `xml:"comments,omitempty"`
+		SupplementaryData SupplementaryData  `xml:"supplementarydata,omitempty"`
+		Sha1Hash          string             `xml:"sha1hash"`
+	}{request.requestHeader, newDeleteCardElement(request.Card), request.Comments, request.SupplementaryData, request.Sha1Hash})
+}
+
+//storedCardCopy copy of card stored against payerRef as cardRef, so the caller's card is left unchanged. The CVN is
+//dropped as it is never stored.
+func storedCardCopy(payerRef string, cardRef string, card *Card) *Card {
+	stored := &Card{}
+	if card != nil {
+		*stored = *card
+	}
+	stored.PayerRef, stored.Ref, stored.CVN = payerRef, cardRef, nil
+	return stored
+}
+
+//storeCardElement card written for card-new
+type storeCardElement struct {
+	Ref            string `xml:"ref"`
+	PayerRef       string `xml:"payerref"`
+	Number         string `xml:"number"`
+	ExpDate        string `xml:"expdate"`
+	CardHolderName string `xml:"chname"`
+	Type           string `xml:"type"`
+}
+
+//editCardElement card written for card-update-card, without the fields left empty
+type editCardElement struct {
+	Ref            string `xml:"ref"`
+	PayerRef       string `xml:"payerref"`
+	Number         string `xml:"number,omitempty"`
+	ExpDate        string `xml:"expdate,omitempty"`
+	CardHolderName string `xml:"chname,omitempty"`
+	Type           string `xml:"type,omitempty"`
+}
+
+//deleteCardElement card written for card-cancel-card
+type deleteCardElement struct {
+	Ref      string `xml:"ref"`
+	PayerRef string `xml:"payerref"`
+}
+
+func newStoreCardElement(card *Card) *storeCardElement {
+	if card == nil {
+		return nil
+	}
+	return &storeCardElement{card.Ref, card.PayerRef, card.Number, card.ExpDate, card.CardHolderName, card.Type}
+}
+
+func newEditCardElement(card *Card) *editCardElement {
+	if card == nil {
+		return nil
+	}
+	return &editCardElement{card.Ref, card.PayerRef, card.Number, card.ExpDate, card.CardHolderName, card.Type}
+}
+
+func newDeleteCardElement(card *Card) *deleteCardElement {
+	if card == nil {
+		return nil
+	}
+	return &deleteCardElement{card.Ref, card.PayerRef}
+}
+
+//payerElement payer written without empty elements, for the typed customer requests
+type payerElement struct {
+	Ref               string               `xml:"ref,attr"`
+	PayerType         string               `xml:"type,attr,omitempty"`
+	Title             string               `xml:"title,attr,omitempty"`
+	FirstName         string               `xml:"firstname,omitempty"`
+	Surname           string               `xml:"surname,omitempty"`
+	Company           string               `xml:"company,omitempty"`
+	Email             string               `xml:"email,omitempty"`
+	DateOfBirth       string               `xml:"dateofbirth,omitempty"`
+	State             string               `xml:"state,omitempty"`
+	PassPhrase        string               `xml:"passphrase,omitempty"`
+	VatNumber         string               `xml:"vatnumber,omitempty"`
+	VariableReference string               `xml:"varref,omitempty"`
+	CustomerNumber    string               `xml:"custnum,omitempty"`
+	Address           *addressElement      `xml:"address,omitempty"`
+	PhoneNumbers      *phoneNumbersElement `xml:"phonenumbers,omitempty"`
+}
+
+type addressElement struct {
+	Line1    string   `xml:"line1,omitempty"`
+	Line2    string   `xml:"line2,omitempty"`
+	Line3    string   `xml:"line3,omitempty"`
+	City     string   `xml:"city,omitempty"`
+	County   string   `xml:"county,omitempty"`
+	PostCode string   `xml:"postcode,omitempty"`
+	Country  *Country `xml:"country,omitempty"`
+}
+
+type phoneNumbersElement struct {
+	Home   string `xml:"home,omitempty"`
+	Work   string `xml:"work,omitempty"`
+	Fax    string `xml:"fax,omitempty"`
+	Mobile string `xml:"mobile,omitempty"`
+}
+
+func newPayerElement(payer *Payer) *payerElement {
+	if payer == nil {
+		return nil
+	}
+	return &payerElement{
+		Ref:               payer.Ref,
+		PayerType:         payer.PayerType,
+		Title:             payer.Title,
+		FirstName:         payer.FirstName,
+		Surname:           payer.Surname,
+		Company:           payer.Company,
+		Email:             payer.Email,
+		DateOfBirth:       payer.DateOfBirth,
+		State:             payer.State,
+		PassPhrase:        payer.PassPhrase,
+		VatNumber:         payer.VatNumber,
+		VariableReference: payer.VariableReference,
+		CustomerNumber:    payer.CustomerNumber,
+		Address:           (*addressElement)(payer.Address),
+		PhoneNumbers:      (*phoneNumbersElement)(payer.PhoneNumbers),
+	}
+}
+
+//Send signs request with the same checks and hash as the matching CardStorageService method and sends it
+func (cardStorage *CardStorageService) Send(request CardStorageOperation) (*ServiceResponse, *http.Response,
+	error) {
+	if err := request.sign(cardStorage); err != nil {
+		return nil, nil, err
+	}
+	return cardStorage.transmitRequest(request)
+}
@@ -0,0 +1,88 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestCardStorageService_Send(t *testing.T) {
+	card := &Card{Number: "4263970000005262", ExpDate: "0525", CardHolderName: "James Mason", Type: CardTypeVisa, CVN: &CVN{Number: "123", PresInd: "1"}}
+	tests := []struct {
+		request        CardStorageOperation
+		requestXMLBody string
+	}{
+		{
+			NewAuthorizeRequest("AiCibJ5UR7utURy_slxhJw", "03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a", &Amount{Amount: "10000", Currency: "CAD"}),
+			`<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><amount currency="CAD">10000</amount><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash></request>`,
+		},
+		{
+			NewValidateRequest("AiCibJ5UR7utURy_slxhJw", "03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a"),
+			`<request type="receipt-in-otb" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>0fc774cd46731deb27883ed3a019fe348bb8c206</sha1hash></request>`,
+		},
+		{
+			NewCreditRequest("AiCibJ5UR7utURy_slxhJw", "03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a", &Amount{Amount: "10000", Currency: "CAD"}),
+			`<request type="payment-out" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><amount currency="CAD">10000</amount><sha1hash>c9b62959861bdcd1088388adebebf7ca87aee3e2</sha1hash></request>`,
+		},
+		{
+			NewCreateCustomerRequest("AiCibJ5UR7utURy_slxhJw", &Payer{Ref: "03e28f0e-492e-80bd-20ec318e9334", FirstName: "James", Surname: "Mason", Address: &Address{PostCode: "W6 9HR", Country: &Country{Code: "GB"}}}),
+			`<request type="payer-new" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><orderid>AiCibJ5UR7utURy_slxhJw</orderid><sha1hash>3478016b235bcdb85449532f0a094ea9bf80702e</sha1hash><payer ref="03e28f0e-492e-80bd-20ec318e9334"><firstname>James</firstname><surname>Mason</surname><address><postcode>W6 9HR</postcode><country ref="GB"></country></address></payer></request>`,
+		},
+		{
+			NewEditCustomerRequest("AiCibJ5UR7utURy_slxhJw", &Payer{Ref: "03e28f0e-492e-80bd-20ec318e9334", Email: "james.mason@example.com"}),
+			`<request type="payer-edit" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><orderid>AiCibJ5UR7utURy_slxhJw</orderid><sha1hash>3478016b235bcdb85449532f0a094ea9bf80702e</sha1hash><payer ref="03e28f0e-492e-80bd-20ec318e9334"><email>james.mason@example.com</email></payer></request>`,
+		},
+		{
+			NewStoreCardRequest("AiCibJ5UR7utURy_slxhJw", "0f357b45-9aa4-4453-a685-c69232e9024f", "3c4af936-483e-a393-f558bec2fb2a", card),
+			`<request type="card-new" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><orderid>AiCibJ5UR7utURy_slxhJw</orderid><card><ref>3c4af936-483e-a393-f558bec2fb2a</ref><payerref>0f357b45-9aa4-4453-a685-c69232e9024f</payerref><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type></card><sha1hash>def31ace3111ab4b6fcccc9ae8ad8d0e49d2e717</sha1hash></request>`,
+		},
+		{
+			NewEditCardRequest("0f357b45-9aa4-4453-a685-c69232e9024f", "3c4af936-483e-a393-f558bec2fb2a", &Card{ExpDate: "0527"}),
+			`<request type="card-update-card" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><card><ref>3c4af936-483e-a393-f558bec2fb2a</ref><payerref>0f357b45-9aa4-4453-a685-c69232e9024f</payerref><expdate>0527</expdate></card><sha1hash>cf0937de00aa3a33fffea5226ed1875eeb247d9d</sha1hash></request>`,
+		},
+		{
+			NewDeleteCardRequest("0f357b45-9aa4-4453-a685-c69232e9024f", "3c4af936-483e-a393-f558bec2fb2a"),
+			`<request type="card-cancel-card" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><card><ref>3c4af936-483e-a393-f558bec2fb2a</ref><payerref>0f357b45-9aa4-4453-a685-c69232e9024f</payerref></card><sha1hash>af687fd6a4ed35118c31e6056920283aaa506691</sha1hash></request>`,
+		},
+	}
+
+	for _, test := range tests {
+		client, mux, _, teardown := setup()
+		//Credit is the only operation signed with the rebate secret
+		client.RebateHashSecret = "Ref0Secret"
+		mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			if got := string(body); got != test.requestXMLBody {
+				t.Errorf("Request Body = %v, want %v", got, test.requestXMLBody)
+			}
+			fmt.Fprint(w, `<response timestamp="20180731090859"><merchantid>MerchantId</merchantid><orderid>N6qsk4kYRZihmPrTXWYS6g</orderid><result>00</result><message>[ test system ] AUTHORISED</message><pasref>14610544313177922</pasref><authcode>12345</authcode><sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash></response>`)
+		})
+
+		response, _, err := client.CardStorage.Send(test.request)
+		if err != nil {
+			t.Errorf("Error performing Client.Do: %v", err)
+		} else if got, want := response.Result, "00"; got != want {
+			t.Errorf("Response Result = %v, want %v", got, want)
+		}
+		teardown()
+	}
+
+	if card.Ref != "" || card.PayerRef != "" || card.CVN == nil {
+		t.Errorf("NewStoreCardRequest changed the caller's card: %+v", card)
+	}
+}
+
+func TestCardStorageService_Send_Invalid(t *testing.T) {
+	client, _ := NewClient()
+
+	_, _, err := client.CardStorage.Send(NewAuthorizeRequest("AiCibJ5UR7utURy_slxhJw", "03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a", nil))
+	if got, want := fmt.Sprint(fieldErrorFields(err)), "[amount]"; got != want {
+		t.Errorf("Send error fields = %v, want %v", got, want)
+	}
+
+	_, _, err = client.CardStorage.Send(&EditCardRequest{})
+	if got, want := fmt.Sprint(fieldErrorFields(err)), "[card]"; got != want {
+		t.Errorf("Send error fields = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package globalpayments
+
+import "fmt"
+
+//Channel the channel a transaction was taken through
+type Channel string
+
+//Channels accepted by Global Payments
+const (
+	ChannelECOM Channel = "ECOM"
+	ChannelMOTO Channel = "MOTO"
+)
+
+//channelFeatures request features each channel allows. CVN may be sent on either channel, so it is not restricted. MOTO
+//transactions are keyed in by the merchant, so they cannot be authenticated with 3D Secure and are never merchant
+//initiated stored credential transactions.
+var channelFeatures = map[Channel]struct {
+	threeDSecure      bool
+	merchantInitiated bool
+}{
+	ChannelECOM: {threeDSecure: true, merchantInitiated: true},
+	ChannelMOTO: {threeDSecure: false, merchantInitiated: false},
+}
+
+//requestChannel returns the request's channel, or the client's default channel if the request does not set one
+func (client *Client) requestChannel(channel Channel) Channel {
+	if channel == "" {
+		return client.Channel
+	}
+	return channel
+}
+
+//validateChannel checks the request only uses features allowed on its channel. Requests without a channel are not checked.
+func validateChannel(channel Channel, mpi *MPI, storedCredential *StoredCredential) error {
+	if channel == "" {
+		return nil
+	}
+	features, ok := channelFeatures[channel]
+	if !ok {
+		return &FieldError{Field: "channel", Message: fmt.Sprintf("unknown channel %v", channel)}
+	}
+	if mpi != nil && !features.threeDSecure {
+		return &FieldError{Field: "mpi", Message: fmt.Sprintf("3D Secure is not allowed on channel %v", channel)}
+	}
+	if storedCredential != nil && storedCredential.Initiator == InitiatorMerchant && !features.merchantInitiated {
+		return &FieldError{Field: "storedcredential.initiator", Message: fmt.Sprintf("merchant initiated transactions are not allowed on channel %v", channel)}
+	}
+	return nil
+}
+
+//validateThreeDSecureChannel checks cardholder authentication is allowed on channel. The 3D Secure requests start the
+//authentication themselves, so they are checked whether or not they carry an MPI.
+func validateThreeDSecureChannel(channel Channel) error {
+	if channel == "" {
+		return nil
+	}
+	features, ok := channelFeatures[channel]
+	if !ok {
+		return &FieldError{Field: "channel", Message: fmt.Sprintf("unknown channel %v", channel)}
+	}
+	if !features.threeDSecure {
+		return &FieldError{Field: "channel", Message: fmt.Sprintf("3D Secure is not allowed on channel %v", channel)}
+	}
+	return nil
+}
+
+//applyChannel sets the client's default channel on the request before it is validated
+func (request *CardStorageRequest) applyChannel(client *Client) {
+	request.Channel = client.requestChannel(request.Channel)
+}
+
+//applyChannel sets the client's default channel on the request before it is validated
+func (request *PaymentRequest) applyChannel(client *Client) {
+	request.Channel = client.requestChannel(request.Channel)
+}
@@ -0,0 +1,182 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaymentsService_Authorize_ClientChannel(t *testing.T) {
+	authRequest := &PaymentRequest{
+		Account: "internet",
+		OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		Amount: &Amount{
+			Amount:   "1001",
+			Currency: "EUR",
+		},
+		Card: &Card{
+			Number:         "4263970000005262",
+			ExpDate:        "0525",
+			CardHolderName: "James Mason",
+			Type:           "VISA",
+		},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.Channel = ChannelMOTO
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="auth" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><channel>MOTO</channel><orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid><amount currency="EUR">1001</amount><card><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type></card><sha1hash>30790d243b2ebe75a2c30ce13d15ba846d3cd402</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	_, _, err := client.Payments.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+}
+
+func TestValidateChannel(t *testing.T) {
+	mpi := &MPI{CAVV: "AAACBllleHchZTBWIGV4AAAAAAA=", XID: "e9dafe706f7142469c45d4877aaf5984", ECI: "5"}
+	tests := []struct {
+		channel          Channel
+		mpi              *MPI
+		storedCredential *StoredCredential
+		field            string
+	}{
+		{ChannelECOM, mpi, MerchantInitiatedStoredCredential(StoredCredentialRecurring, "MMC0F00YE4000000715"), ""},
+		{ChannelMOTO, nil, FirstStoredCredential(StoredCredentialOneOff), ""},
+		{ChannelMOTO, mpi, nil, "mpi"},
+		{ChannelMOTO, nil, MerchantInitiatedStoredCredential(StoredCredentialRecurring, "MMC0F00YE4000000715"), "storedcredential.initiator"},
+		{"POS", nil, nil, "channel"},
+	}
+
+	for _, test := range tests {
+		err := validateChannel(test.channel, test.mpi, test.storedCredential)
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("validateChannel %v error = %v, want nil", test.channel, err)
+			}
+			continue
+		}
+		fieldError, ok := err.(*FieldError)
+		if !ok || fieldError.Field != test.field {
+			t.Errorf("validateChannel %v error = %v, want field %v", test.channel, err, test.field)
+		}
+	}
+}
+
+func TestThreeDSecure_ChannelMOTO(t *testing.T) {
+	fixNow(t)
+	moto := func(client *Client) {
+		client.Channel = ChannelMOTO
+	}
+	client, _ := NewClient(moto)
+	storedCardRequest := func() *CardStorageRequest {
+		return &CardStorageRequest{
+			OrderID:       "AiCibJ5UR7utURy_slxhJw",
+			PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+			PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+			Amount:        &Amount{Amount: "10000", Currency: "CAD"},
+		}
+	}
+	paymentRequest := func() *PaymentRequest {
+		return &PaymentRequest{
+			OrderID: "AiCibJ5UR7utURy_slxhJw",
+			Amount:  &Amount{Amount: "10000", Currency: "CAD"},
+			Card:    &Card{Number: "4263970000005262", ExpDate: "0525", CardHolderName: "James Mason"},
+			PaRes:   "eJxVUttuwjAM/ZWq7zRJaVNAbhAbTENCgIBpe81atzRaL6Mpg/39krYwJuXBx/axj4/D51HH6TrN2sbWo",
+		}
+	}
+
+	calls := map[string]func() error{
+		"CardStorage.VerifyEnrolled": func() error {
+			_, _, err := client.CardStorage.VerifyEnrolled(storedCardRequest())
+			return err
+		},
+		"Payments.VerifyEnrolled": func() error {
+			_, _, err := client.Payments.VerifyEnrolled(paymentRequest())
+			return err
+		},
+		"Payments.VerifySig": func() error {
+			_, _, err := client.Payments.VerifySig(paymentRequest())
+			return err
+		},
+		"ThreeDSecure.CheckVersion": func() error {
+			_, _, err := client.ThreeDSecure.CheckVersion(&CheckVersionRequest{Number: "4263970000005262"})
+			return err
+		},
+		"ThreeDSecure.InitiateAuthentication": func() error {
+			_, _, err := client.ThreeDSecure.InitiateAuthentication(&AuthenticationRequest{ServerTransID: "af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5"})
+			return err
+		},
+		"ThreeDSecure.GetResult": func() error {
+			_, _, err := client.ThreeDSecure.GetResult("af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5", "")
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		err := call()
+		if got := strings.Join(fieldErrorFields(err), ","); got != "channel" {
+			t.Errorf("%v error = %v, want a channel field error", name, err)
+		}
+	}
+}
+
+func TestThreeDSecureService_RequestChannel(t *testing.T) {
+	client, mux, teardown := setupThreeDSecure()
+	defer teardown()
+	mux.HandleFunc("/3ds2/protocol-versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"enrolled": "ENROLLED"}`)
+	})
+	mux.HandleFunc("/3ds2/authentications/af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "AUTHENTICATION_SUCCESSFUL"}`)
+	})
+
+	client.Channel = ChannelMOTO
+	if _, _, err := client.ThreeDSecure.CheckVersion(&CheckVersionRequest{Number: "4263970000005262", Channel: ChannelECOM}); err != nil {
+		t.Errorf("CheckVersion error = %v for an ECOM request on a MOTO client, want nil", err)
+	}
+	if _, _, err := client.ThreeDSecure.GetResult("af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5", ChannelECOM); err != nil {
+		t.Errorf("GetResult error = %v for an ECOM request on a MOTO client, want nil", err)
+	}
+
+	client.Channel = ChannelECOM
+	calls := map[string]func() error{
+		"CheckVersion": func() error {
+			_, _, err := client.ThreeDSecure.CheckVersion(&CheckVersionRequest{Number: "4263970000005262", Channel: ChannelMOTO})
+			return err
+		},
+		"InitiateAuthentication": func() error {
+			_, _, err := client.ThreeDSecure.InitiateAuthentication(&AuthenticationRequest{ServerTransID: "af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5", Channel: ChannelMOTO})
+			return err
+		},
+		"GetResult": func() error {
+			_, _, err := client.ThreeDSecure.GetResult("af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5", ChannelMOTO)
+			return err
+		},
+	}
+	for name, call := range calls {
+		if got := strings.Join(fieldErrorFields(call()), ","); got != "channel" {
+			t.Errorf("%v error fields = %v for a MOTO request on an ECOM client, want channel", name, got)
+		}
+	}
+}
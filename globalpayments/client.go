@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/xml"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"net/url"
@@ -14,14 +15,24 @@ import (
 
 // Client manages communication with Global Payments API
 type Client struct {
-	HTTPClient       *http.Client
-	BaseURL          *url.URL
-	HashSecret       string
-	RebateHashSecret string
-	MerchantID       string
-	APIPath          string
+	HTTPClient          *http.Client
+	BaseURL             *url.URL
+	ThreeDSecureBaseURL *url.URL
+	HPPURL              *url.URL
+	HPPCardUpdateURL    *url.URL
+	HashSecret          string
+	RebateHashSecret    string
+	MerchantID          string
+	APIPath             string
+	//Channel sent on requests that do not set their own
+	Channel Channel
 	// Services used for communicating different actions of Global Payments API
-	CardStorage *CardStorageService
+	CardStorage  *CardStorageService
+	Payments     *PaymentsService
+	Schedules    *SchedulesService
+	ThreeDSecure *ThreeDSecureService
+	HPP          *HostedPaymentService
+	APM          *APMService
 }
 
 type service struct {
@@ -36,6 +47,39 @@ const (
 	DefaultHashSecret = "Po8lRRT67a"
 	DefaultRebateHash = "Po8lRRT67a"
 	DefaultPath       = "/epage-remote.cgi"
+
+	DefaultThreeDSecureBaseURL = "https://api.sandbox.globalpay-ecommerce.com"
+	DefaultThreeDSecurePath    = "/3ds2"
+
+	DefaultHPPURL           = "https://pay.sandbox.realexpayments.com/pay"
+	DefaultHPPCardUpdateURL = "https://pay.sandbox.realexpayments.com/card-update"
+)
+
+//Request types sent in the type attribute of the XML API requests
+const (
+	requestTypeAuth                    = "auth"
+	requestTypeOffline                 = "offline"
+	requestTypeManual                  = "manual"
+	requestTypeOTB                     = "otb"
+	requestTypeDCCRate                 = "dccrate"
+	requestTypeVerifyEnrolled          = "3ds-verifyenrolled"
+	requestTypeVerifySig               = "3ds-verifysig"
+	requestTypeReceiptIn               = "receipt-in"
+	requestTypeReceiptInOTB            = "receipt-in-otb"
+	requestTypePaymentOut              = "payment-out"
+	requestTypePayerNew                = "payer-new"
+	requestTypePayerEdit               = "payer-edit"
+	requestTypeCardNew                 = "card-new"
+	requestTypeCardUpdate              = "card-update-card"
+	requestTypeCardCancel              = "card-cancel-card"
+	requestTypeRealvaultDCCRate        = "realvault-dccrate"
+	requestTypeRealvaultVerifyEnrolled = "realvault-3ds-verifyenrolled"
+	requestTypeScheduleNew             = "schedule-new"
+	requestTypeScheduleGet             = "schedule-get"
+	requestTypeScheduleDelete          = "schedule-delete"
+	requestTypeScheduleSearch          = "schedule-search"
+	requestTypeAPMPaymentSet           = "payment-set"
+	requestTypeMobileAuth              = "auth-mobile"
 )
 
 // Global Payment Error values
@@ -50,6 +94,16 @@ func (err *ValidationError) Error() string {
 		err.Response.Request.URL.Path, err.Response.StatusCode)
 }
 
+//FieldError for requests that contain a field Global Payments would reject, returned before the request is signed and sent
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (err *FieldError) Error() string {
+	return fmt.Sprintf("Field Error: field: %v, %v", err.Field, err.Message)
+}
+
 // NewClient returns a Global Payments API Client. If no functional options are provided, Default values will be used to initiate the client.
 // Note: Default Values initiate requests to Global payments test environment.
 // The services of a client divide the API into logical chunks and correspond to the structure of the Global Payments documentation at https://developer.globalpay.com/api/getting-started.
@@ -63,10 +117,33 @@ func NewClient(options ...func(*Client)) (*Client, error) {
 		return nil, err
 	}
 
-	client := &Client{HTTPClient: httpClient, BaseURL: baseURL, HashSecret: DefaultHashSecret,
-		MerchantID: DefaultMerchantID, RebateHashSecret: DefaultRebateHash}
+	threeDSecureBaseURL, err := url.Parse(DefaultThreeDSecureBaseURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hppURL, err := url.Parse(DefaultHPPURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hppCardUpdateURL, err := url.Parse(DefaultHPPCardUpdateURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{HTTPClient: httpClient, BaseURL: baseURL, ThreeDSecureBaseURL: threeDSecureBaseURL, HPPURL: hppURL,
+		HPPCardUpdateURL: hppCardUpdateURL, HashSecret: DefaultHashSecret, MerchantID: DefaultMerchantID, RebateHashSecret: DefaultRebateHash}
 
 	client.CardStorage = &CardStorageService{service: service{client: client, Path: DefaultPath}}
+	client.Payments = &PaymentsService{service: service{client: client, Path: DefaultPath}}
+	client.Schedules = &SchedulesService{service: service{client: client, Path: DefaultPath}}
+	client.ThreeDSecure = &ThreeDSecureService{service: service{client: client, Path: DefaultThreeDSecurePath}}
+	client.HPP = &HostedPaymentService{service: service{client: client}}
+	client.APM = &APMService{service: service{client: client, Path: DefaultPath}}
 
 	for _, option := range options {
 		option(client)
@@ -76,6 +153,10 @@ func NewClient(options ...func(*Client)) (*Client, error) {
 		return nil, fmt.Errorf("baseURL %q contains a trailing slash", client.BaseURL)
 	}
 
+	if strings.HasSuffix(client.ThreeDSecureBaseURL.Path, "/") {
+		return nil, fmt.Errorf("threeDSecureBaseURL %q contains a trailing slash", client.ThreeDSecureBaseURL)
+	}
+
 	return client, nil
 }
 
@@ -129,6 +210,7 @@ func (client *Client) Do(req *http.Request, v interface{}) (*http.Response, erro
 type serviceAuthenticator struct {
 	elementsToHash []string
 	sharedSecret   string
+	hashFunc       func() hash.Hash
 }
 
 //Marshaller interface for marshalling data
@@ -143,13 +225,19 @@ type Authenticator interface {
 	buildSignature() (signature string, err error)
 }
 
+//buildSignature hashes the elements with SHA1 unless another hash function has been set, for example SHA256 for the HPP
 func (authenticator *serviceAuthenticator) buildSignature() (signature string, err error) {
-	hashedElementsString, err := authenticator.hashAndEncode(sha1.New(), strings.Join(authenticator.elementsToHash, "."))
+	hashFunc := authenticator.hashFunc
+	if hashFunc == nil {
+		hashFunc = sha1.New
+	}
+
+	hashedElementsString, err := authenticator.hashAndEncode(hashFunc(), strings.Join(authenticator.elementsToHash, "."))
 	if err != nil {
 		return "", err
 	}
 
-	signature, err = authenticator.hashAndEncode(sha1.New(), hashedElementsString+"."+authenticator.sharedSecret)
+	signature, err = authenticator.hashAndEncode(hashFunc(), hashedElementsString+"."+authenticator.sharedSecret)
 	if err != nil {
 		return "", err
 	}
@@ -168,23 +256,27 @@ func (authenticator *serviceAuthenticator) hashAndEncode(m Marshaller, str strin
 
 //ServiceResponse all Global payments requests contain the same response structure
 type ServiceResponse struct {
-	XMLName             xml.Name    `xml:"response"`
-	Timestamp           string      `xml:"timestamp,attr"`
-	MerchantID          string      `xml:"merchantid"`
-	Account             string      `xml:"account"`
-	OrderID             string      `xml:"orderid"`
-	AuthCode            string      `xml:"authcode"`
-	Result              string      `xml:"result"`
-	CVNResult           string      `xml:"cvnresult"`
-	AVSPostcodeResponse string      `xml:"avspostcoderesponse"`
-	AVSAddressResponse  string      `xml:"avsaddressresponse"`
-	BatchID             string      `xml:"batchid"`
-	Message             string      `xml:"message"`
-	PasRef              string      `xml:"pasref"`
-	TimeTaken           string      `xml:"timetaken"`
-	AuthTimeTaken       string      `xml:"authtimetaken"`
-	CardIssuer          *CardIssuer `xml:"cardissuer"`
-	Sha1Hash            string      `xml:"sha1hash"`
+	XMLName             xml.Name       `xml:"response"`
+	Timestamp           string         `xml:"timestamp,attr"`
+	MerchantID          string         `xml:"merchantid"`
+	Account             string         `xml:"account"`
+	OrderID             string         `xml:"orderid"`
+	AuthCode            string         `xml:"authcode"`
+	Result              string         `xml:"result"`
+	CVNResult           string         `xml:"cvnresult"`
+	AVSPostcodeResponse string         `xml:"avspostcoderesponse"`
+	AVSAddressResponse  string         `xml:"avsaddressresponse"`
+	BatchID             string         `xml:"batchid"`
+	Message             string         `xml:"message"`
+	PasRef              string         `xml:"pasref"`
+	TimeTaken           string         `xml:"timetaken"`
+	AuthTimeTaken       string         `xml:"authtimetaken"`
+	SRD                 string         `xml:"srd"`
+	CardIssuer          *CardIssuer    `xml:"cardissuer"`
+	DCCInfo             *DCCRate       `xml:"dccinfo"`
+	FraudResponse       *FraudResponse `xml:"fraudresponse"`
+	TSS                 *TSSResult     `xml:"tss"`
+	Sha1Hash            string         `xml:"sha1hash"`
 	serviceAuthenticator
 }
 
@@ -196,6 +288,26 @@ type CardIssuer struct {
 	Region      string `xml:"region"`
 }
 
+//CVNMatched reports whether the issuer matched the CVN sent with the request
+func (response *ServiceResponse) CVNMatched() bool {
+	return response.CVN().Matched()
+}
+
+//CVNMismatched reports whether the issuer checked the CVN sent with the request and it did not match
+func (response *ServiceResponse) CVNMismatched() bool {
+	return response.CVN().Mismatched()
+}
+
+//AVSFullMatch reports whether both the postcode and the address sent with the request were matched by the issuer
+func (response *ServiceResponse) AVSFullMatch() bool {
+	return response.AVSPostcode().Matched() && response.AVSAddress().Matched()
+}
+
+//AVSMismatched reports whether the issuer checked the postcode or address sent with the request and either did not match
+func (response *ServiceResponse) AVSMismatched() bool {
+	return response.AVSPostcode().Mismatched() || response.AVSAddress().Mismatched()
+}
+
 //ResponseAuthenticator interface for response validation of signature
 type ResponseAuthenticator interface {
 	Authenticator
@@ -219,31 +331,47 @@ type Transmitter interface {
 		err error)
 }
 
+//hashedResponse for response structs that extend ServiceResponse and are signed with the same response hash
+type hashedResponse interface {
+	serviceResponse() *ServiceResponse
+}
+
+func (response *ServiceResponse) serviceResponse() *ServiceResponse {
+	return response
+}
+
 func (transmitter *service) transmitRequest(request interface{}) (response *ServiceResponse, httpResponse *http.Response,
 	err error) {
 
 	response = &ServiceResponse{}
+	httpResponse, err = transmitter.transmit(request, response)
 	if err != nil {
-		return nil, nil, err
+		return nil, httpResponse, err
 	}
 
+	return response, httpResponse, nil
+}
+
+func (transmitter *service) transmit(request interface{}, v hashedResponse) (httpResponse *http.Response, err error) {
+
 	httpRequest, err := transmitter.client.NewRequest("POST", transmitter.Path, request)
 
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	httpResponse, err = transmitter.client.Do(httpRequest, response)
+	httpResponse, err = transmitter.client.Do(httpRequest, v)
 	if err != nil {
-		return nil, httpResponse, err
+		return httpResponse, err
 	}
 
+	response := v.serviceResponse()
 	response.elementsToHash = []string{response.Timestamp, response.MerchantID, response.OrderID, response.Result, response.Message, response.PasRef, response.AuthCode}
 	response.sharedSecret = transmitter.client.HashSecret
 	err = response.validateResponseHash(httpResponse)
 	if err != nil {
-		return nil, httpResponse, err
+		return httpResponse, err
 	}
 
-	return response, httpResponse, nil
+	return httpResponse, nil
 }
@@ -32,6 +32,10 @@ func TestClient_NewClient_DefaultValues(t *testing.T) {
 		t.Errorf("NewClient baseURL is %v, want %v", got, want)
 	}
 
+	if got, want := client.ThreeDSecureBaseURL.String(), DefaultThreeDSecureBaseURL; got != want {
+		t.Errorf("NewClient ThreeDSecureBaseURL is %v, want %v", got, want)
+	}
+
 	if got, want := client.HashSecret, DefaultHashSecret; got != want {
 		t.Errorf("NewClient HashSecret is %v, want %v", got, want)
 	}
@@ -371,3 +375,31 @@ func Test_Transmitter_transmitRequest_invalid(t *testing.T) {
 		t.Errorf("Response supposed to be nil, got: %v", response)
 	}
 }
+
+func TestServiceResponse_CVNAndAVSResults(t *testing.T) {
+	cases := []struct {
+		response                    *ServiceResponse
+		cvnMatched, cvnMismatched   bool
+		avsFullMatch, avsMismatched bool
+	}{
+		{&ServiceResponse{CVNResult: "M", AVSPostcodeResponse: "M", AVSAddressResponse: "M"}, true, false, true, false},
+		{&ServiceResponse{CVNResult: "N", AVSPostcodeResponse: "M", AVSAddressResponse: "N"}, false, true, false, true},
+		{&ServiceResponse{CVNResult: "U", AVSPostcodeResponse: "I", AVSAddressResponse: "I"}, false, false, false, false},
+		{&ServiceResponse{}, false, false, false, false},
+	}
+
+	for _, c := range cases {
+		if got := c.response.CVNMatched(); got != c.cvnMatched {
+			t.Errorf("CVNMatched for %q is %v, want %v", c.response.CVNResult, got, c.cvnMatched)
+		}
+		if got := c.response.CVNMismatched(); got != c.cvnMismatched {
+			t.Errorf("CVNMismatched for %q is %v, want %v", c.response.CVNResult, got, c.cvnMismatched)
+		}
+		if got := c.response.AVSFullMatch(); got != c.avsFullMatch {
+			t.Errorf("AVSFullMatch for %q/%q is %v, want %v", c.response.AVSPostcodeResponse, c.response.AVSAddressResponse, got, c.avsFullMatch)
+		}
+		if got := c.response.AVSMismatched(); got != c.avsMismatched {
+			t.Errorf("AVSMismatched for %q/%q is %v, want %v", c.response.AVSPostcodeResponse, c.response.AVSAddressResponse, got, c.avsMismatched)
+		}
+	}
+}
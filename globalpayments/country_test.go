@@ -0,0 +1,21 @@
+package globalpayments
+
+import "testing"
+
+func TestCountry_NumericCode(t *testing.T) {
+	for code, want := range map[string]string{"GB": "826", "IE": "372", "US": "840", "826": "826"} {
+		country := &Country{Code: code}
+		got, err := country.NumericCode()
+		if err != nil {
+			t.Errorf("Error converting country %v: %v", code, err)
+		}
+		if got != want {
+			t.Errorf("Country %v NumericCode = %v, want %v", code, got, want)
+		}
+	}
+
+	country := &Country{Code: "XX"}
+	if _, err := country.NumericCode(); err == nil {
+		t.Errorf("Country XX NumericCode returned no error")
+	}
+}
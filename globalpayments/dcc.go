@@ -0,0 +1,121 @@
+package globalpayments
+
+import (
+	"net/http"
+	"strconv"
+)
+
+//DCC values accepted by Global Payments
+const (
+	DCCProcessorFexco = "fexco"
+	DCCTypeRateLookup = "1"
+)
+
+//DCCRateType the kind of transaction a DCC rate is looked up and applied for
+type DCCRateType string
+
+//DCC rate types accepted by Global Payments
+const (
+	DCCRateTypeSale DCCRateType = "S"
+)
+
+//DCCInfo request struct. For a rate lookup only CCP, Type and RateType are sent, the authorization that follows carries
+//the rate and cardholder amount the customer accepted.
+type DCCInfo struct {
+	CCP      string      `xml:"ccp"`
+	Type     string      `xml:"type"`
+	RateType DCCRateType `xml:"ratetype,omitempty"`
+	Rate     string      `xml:"rate,omitempty"`
+	Amount   *Amount     `xml:"amount,omitempty"`
+}
+
+//DCCRate response struct for the rate offered to the cardholder in their own currency
+type DCCRate struct {
+	CardholderCurrency          string `xml:"cardholdercurrency"`
+	CardholderAmount            string `xml:"cardholderamount"`
+	CardholderRate              string `xml:"cardholderrate"`
+	MerchantCurrency            string `xml:"merchantcurrency"`
+	MerchantAmount              string `xml:"merchantamount"`
+	MarginRatePercentage        string `xml:"marginratepercentage"`
+	ExchangeRateSourceName      string `xml:"exchangeratesourcename"`
+	CommissionPercentage        string `xml:"commissionpercentage"`
+	ExchangeRateSourceTimestamp string `xml:"exchangeratesourcetimestamp"`
+}
+
+//NewDCCRateLookup returns the dccinfo for a rate lookup of rateType with the given currency conversion processor
+func NewDCCRateLookup(ccp string, rateType DCCRateType) *DCCInfo {
+	return &DCCInfo{CCP: ccp, Type: DCCTypeRateLookup, RateType: rateType}
+}
+
+//CardholderMoney the amount offered to the cardholder in their own currency
+func (rate *DCCRate) CardholderMoney() (Money, error) {
+	return (&Amount{Amount: rate.CardholderAmount, Currency: rate.CardholderCurrency}).Money()
+}
+
+//MerchantMoney the amount of the transaction in the merchant's currency
+func (rate *DCCRate) MerchantMoney() (Money, error) {
+	return (&Amount{Amount: rate.MerchantAmount, Currency: rate.MerchantCurrency}).Money()
+}
+
+//ExchangeRate the rate offered to the cardholder, in cardholder currency per unit of merchant currency
+func (rate *DCCRate) ExchangeRate() (float64, error) {
+	exchangeRate, err := strconv.ParseFloat(rate.CardholderRate, 64)
+	if err != nil {
+		return 0, &FieldError{Field: "dccinfo.cardholderrate", Message: rate.CardholderRate + " is not a rate"}
+	}
+	return exchangeRate, nil
+}
+
+//Accept returns the dccinfo to send on the authorization once the cardholder has accepted the rate offered by the lookup
+//of rateType
+func (rate *DCCRate) Accept(ccp string, rateType DCCRateType) *DCCInfo {
+	return &DCCInfo{
+		CCP:      ccp,
+		Type:     DCCTypeRateLookup,
+		RateType: rateType,
+		Rate:     rate.CardholderRate,
+		Amount:   &Amount{Amount: rate.CardholderAmount, Currency: rate.CardholderCurrency},
+	}
+}
+
+//DCCRate Dynamic Currency Conversion rate lookup against a stored card. The offered rate is returned in the response DCCInfo
+//and, if the customer accepts it, is sent with DCCRate.Accept on the following Authorize.
+func (cardStorage *CardStorageService) DCCRate(request *CardStorageRequest) (*ServiceResponse, *http.Response,
+	error) {
+	request.applyChannel(cardStorage.client)
+	if err := request.validate(requestTypeRealvaultDCCRate); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = cardStorage.client.MerchantID
+	request.Type = requestTypeRealvaultDCCRate
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PayerRef}
+	request.sharedSecret = cardStorage.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return cardStorage.transmitRequest(request)
+}
+
+//DCCRate Dynamic Currency Conversion rate lookup against the card data supplied with the request. The offered rate is returned
+//in the response DCCInfo and, if the customer accepts it, is sent with DCCRate.Accept on the following Authorize.
+func (payments *PaymentsService) DCCRate(request *PaymentRequest) (*ServiceResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeDCCRate); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeDCCRate
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitRequest(request)
+}
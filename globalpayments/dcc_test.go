@@ -0,0 +1,177 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const dccRateResponseXMLBody = `<response timestamp="20180731090859">
+								   <merchantid>MerchantId</merchantid>
+								   <account>internet</account>
+								   <orderid>AiCibJ5UR7utURy_slxhJw</orderid>
+								   <result>00</result>
+								   <message>Successful</message>
+								   <pasref>14610544313177922</pasref>
+								   <dccinfo>
+									  <cardholdercurrency>GBP</cardholdercurrency>
+									  <cardholderamount>13049</cardholderamount>
+									  <cardholderrate>0.6868</cardholderrate>
+									  <merchantcurrency>EUR</merchantcurrency>
+									  <merchantamount>19000</merchantamount>
+									  <marginratepercentage>3.75</marginratepercentage>
+									  <exchangeratesourcename>REUTERS WHOLESALE INTERBANK</exchangeratesourcename>
+									  <commissionpercentage>0</commissionpercentage>
+									  <exchangeratesourcetimestamp>20180613 1215</exchangeratesourcetimestamp>
+								   </dccinfo>
+								   <sha1hash>214176ee3192d2ac383684f71d195b893f36061a</sha1hash>
+								</response>`
+
+var expectedDCCRate = &DCCRate{
+	CardholderCurrency:          "GBP",
+	CardholderAmount:            "13049",
+	CardholderRate:              "0.6868",
+	MerchantCurrency:            "EUR",
+	MerchantAmount:              "19000",
+	MarginRatePercentage:        "3.75",
+	ExchangeRateSourceName:      "REUTERS WHOLESALE INTERBANK",
+	CommissionPercentage:        "0",
+	ExchangeRateSourceTimestamp: "20180613 1215",
+}
+
+func TestDCCRate_Accept(t *testing.T) {
+	dccInfo := expectedDCCRate.Accept(DCCProcessorFexco, DCCRateTypeSale)
+
+	expectedDCCInfo := &DCCInfo{CCP: "fexco", Type: "1", RateType: "S", Rate: "0.6868", Amount: &Amount{Amount: "13049", Currency: "GBP"}}
+	if !reflect.DeepEqual(dccInfo, expectedDCCInfo) {
+		t.Errorf("DCCRate.Accept = %v, want %v", dccInfo, expectedDCCInfo)
+	}
+}
+
+func TestDCCRate_TypedValues(t *testing.T) {
+	cardholder, err := expectedDCCRate.CardholderMoney()
+	if err != nil || cardholder != NewMoney(13049, "GBP") {
+		t.Errorf("DCCRate CardholderMoney = %v, %v, want 130.49 GBP", cardholder, err)
+	}
+
+	merchant, err := expectedDCCRate.MerchantMoney()
+	if err != nil || merchant != NewMoney(19000, "EUR") {
+		t.Errorf("DCCRate MerchantMoney = %v, %v, want 190.00 EUR", merchant, err)
+	}
+
+	rate, err := expectedDCCRate.ExchangeRate()
+	if err != nil || rate != 0.6868 {
+		t.Errorf("DCCRate ExchangeRate = %v, %v, want 0.6868", rate, err)
+	}
+
+	if _, err := (&DCCRate{CardholderRate: "n/a"}).ExchangeRate(); err == nil {
+		t.Errorf("DCCRate ExchangeRate returned no error for an invalid rate")
+	}
+}
+
+func TestCardStorageService_DCCRate(t *testing.T) {
+	rateRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "19000",
+			Currency: "EUR",
+		},
+		DCCInfo: NewDCCRateLookup(DCCProcessorFexco, DCCRateTypeSale),
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="realvault-dccrate" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>7df769491ca6f8f2882882dc428fdc0ffe56f8a1</sha1hash><amount currency="EUR">19000</amount><dccinfo><ccp>fexco</ccp><type>1</type><ratetype>S</ratetype></dccinfo></request>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, dccRateResponseXMLBody)
+	})
+
+	response, _, err := client.CardStorage.DCCRate(rateRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if !reflect.DeepEqual(response.DCCInfo, expectedDCCRate) {
+		t.Errorf("Response DCCInfo = %v, want %v", response.DCCInfo, expectedDCCRate)
+	}
+}
+
+func TestCardStorageService_Authorize_DCC(t *testing.T) {
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "19000",
+			Currency: "EUR",
+		},
+		DCCInfo: expectedDCCRate.Accept(DCCProcessorFexco, DCCRateTypeSale),
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>7df769491ca6f8f2882882dc428fdc0ffe56f8a1</sha1hash><amount currency="EUR">19000</amount><dccinfo><ccp>fexco</ccp><type>1</type><ratetype>S</ratetype><rate>0.6868</rate><amount currency="GBP">13049</amount></dccinfo></request>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, dccRateResponseXMLBody)
+	})
+
+	_, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+}
+
+func TestPaymentsService_DCCRate(t *testing.T) {
+	rateRequest := &PaymentRequest{
+		Account: "internet",
+		OrderID: "AiCibJ5UR7utURy_slxhJw",
+		Amount: &Amount{
+			Amount:   "19000",
+			Currency: "EUR",
+		},
+		Card: &Card{
+			Number:         "4263970000005262",
+			ExpDate:        "0525",
+			CardHolderName: "James Mason",
+			Type:           "VISA",
+		},
+		DCCInfo: NewDCCRateLookup(DCCProcessorFexco, DCCRateTypeSale),
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="dccrate" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><amount currency="EUR">19000</amount><card><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type></card><dccinfo><ccp>fexco</ccp><type>1</type><ratetype>S</ratetype></dccinfo><sha1hash>27e5315881964e7ad46603e0b11585180f252f3c</sha1hash></request>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, dccRateResponseXMLBody)
+	})
+
+	response, _, err := client.Payments.DCCRate(rateRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if !reflect.DeepEqual(response.DCCInfo, expectedDCCRate) {
+		t.Errorf("Response DCCInfo = %v, want %v", response.DCCInfo, expectedDCCRate)
+	}
+}
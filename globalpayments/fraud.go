@@ -0,0 +1,110 @@
+package globalpayments
+
+import "encoding/xml"
+
+//FraudFilterMode sets how the fraud filter treats a request
+type FraudFilterMode string
+
+//FraudAction is the outcome of the fraud filter or of one of its rules
+type FraudAction string
+
+//Fraud filter values accepted and returned by Global Payments. In passive mode rules are run and reported but never hold
+//or block the transaction.
+const (
+	FraudFilterActive  FraudFilterMode = "ACTIVE"
+	FraudFilterPassive FraudFilterMode = "PASSIVE"
+	FraudFilterOff     FraudFilterMode = "OFF"
+
+	FraudPass        FraudAction = "PASS"
+	FraudHold        FraudAction = "HOLD"
+	FraudBlock       FraudAction = "BLOCK"
+	FraudNotExecuted FraudAction = "NOT_EXECUTED"
+)
+
+//FraudFilter request struct. Rules overrides the mode of individual rules for this request.
+type FraudFilter struct {
+	Mode  FraudFilterMode `xml:"mode,attr"`
+	Rules FraudRules      `xml:"rules,omitempty"`
+}
+
+//FraudRules request struct
+type FraudRules []FraudRule
+
+//MarshalXML wraps each rule in a rule element
+func (rules FraudRules) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(encoder, start, "rule", []FraudRule(rules))
+}
+
+//encodeWrapped encodes each value of a slice as a child of start. Go's "parent>child" tags still write the parent element
+//for an empty slice, which some request types reject.
+func encodeWrapped(encoder *xml.Encoder, start xml.StartElement, name string, values interface{}) error {
+	err := encoder.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	err = encoder.EncodeElement(values, xml.StartElement{Name: xml.Name{Local: name}})
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+//FraudRule request struct
+type FraudRule struct {
+	ID   string          `xml:"id,attr"`
+	Mode FraudFilterMode `xml:"mode,attr"`
+}
+
+//FraudResponse response struct for the fraud filter result and the action of each rule that was run
+type FraudResponse struct {
+	Mode   FraudFilterMode   `xml:"mode,attr"`
+	Result FraudAction       `xml:"result"`
+	Rules  []FraudRuleResult `xml:"rules>rule"`
+}
+
+//FraudRuleResult response struct
+type FraudRuleResult struct {
+	ID     string      `xml:"id,attr"`
+	Name   string      `xml:"name,attr"`
+	Action FraudAction `xml:"action"`
+}
+
+//NewFraudFilter returns the fraud filter settings for a request
+func NewFraudFilter(mode FraudFilterMode) *FraudFilter {
+	return &FraudFilter{Mode: mode}
+}
+
+//Held reports whether the fraud filter or one of its rules held the transaction for review. In passive mode results are
+//only reported, so it is always false.
+func (fraud *FraudResponse) Held() bool {
+	return fraud.hasAction(FraudHold)
+}
+
+//Blocked reports whether the fraud filter or one of its rules blocked the transaction. In passive mode results are only
+//reported, so it is always false.
+func (fraud *FraudResponse) Blocked() bool {
+	return fraud.hasAction(FraudBlock)
+}
+
+func (fraud *FraudResponse) hasAction(action FraudAction) bool {
+	if fraud.Mode == FraudFilterPassive {
+		return false
+	}
+	if fraud.Result == action {
+		return true
+	}
+	for _, rule := range fraud.Rules {
+		if rule.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+//FraudHeldOrBlocked reports whether the transaction was held or blocked by fraud rules
+func (response *ServiceResponse) FraudHeldOrBlocked() bool {
+	if response.FraudResponse == nil {
+		return false
+	}
+	return response.FraudResponse.Held() || response.FraudResponse.Blocked()
+}
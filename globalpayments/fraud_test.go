@@ -0,0 +1,111 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCardStorageService_Authorize_FraudFilter(t *testing.T) {
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		FraudFilter: &FraudFilter{Mode: FraudFilterActive, Rules: FraudRules{{ID: "a0a7f2a4-5d0a-4d8b-8d6f-0f4b6bb0a5e1", Mode: FraudFilterPassive}}},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount><fraudfilter mode="ACTIVE"><rules><rule id="a0a7f2a4-5d0a-4d8b-8d6f-0f4b6bb0a5e1" mode="PASSIVE"></rule></rules></fraudfilter></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <fraudresponse mode="ACTIVE">
+								  <result>HOLD</result>
+								  <rules>
+									 <rule id="a0a7f2a4-5d0a-4d8b-8d6f-0f4b6bb0a5e1" name="High Value">
+										<action>PASS</action>
+									 </rule>
+									 <rule id="7a0f3d2c-8a4e-4b6f-9c1d-2e5f6a7b8c9d" name="Country Mismatch">
+										<action>HOLD</action>
+									 </rule>
+								  </rules>
+							   </fraudresponse>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedFraudResponse := &FraudResponse{
+		Mode:   FraudFilterActive,
+		Result: FraudHold,
+		Rules: []FraudRuleResult{
+			{ID: "a0a7f2a4-5d0a-4d8b-8d6f-0f4b6bb0a5e1", Name: "High Value", Action: FraudPass},
+			{ID: "7a0f3d2c-8a4e-4b6f-9c1d-2e5f6a7b8c9d", Name: "Country Mismatch", Action: FraudHold},
+		},
+	}
+	if !reflect.DeepEqual(response.FraudResponse, expectedFraudResponse) {
+		t.Errorf("Response FraudResponse = %v, want %v", response.FraudResponse, expectedFraudResponse)
+	}
+
+	if !response.FraudHeldOrBlocked() {
+		t.Errorf("Response FraudHeldOrBlocked = false for %v", response.FraudResponse.Result)
+	}
+}
+
+func TestFraudResponse_HeldAndBlocked(t *testing.T) {
+	fraud := &FraudResponse{Result: FraudPass, Rules: []FraudRuleResult{{Action: FraudPass}, {Action: FraudBlock}}}
+	if fraud.Held() || !fraud.Blocked() {
+		t.Errorf("FraudResponse Held = %v, Blocked = %v, want false, true", fraud.Held(), fraud.Blocked())
+	}
+
+	response := &ServiceResponse{}
+	if response.FraudHeldOrBlocked() {
+		t.Errorf("Response FraudHeldOrBlocked = true without a fraud response")
+	}
+}
+
+func TestNewFraudFilter_WithoutRules(t *testing.T) {
+	encoded, err := xml.Marshal(NewFraudFilter(FraudFilterPassive))
+	if err != nil {
+		t.Errorf("Error encoding fraud filter: %v", err)
+	}
+	if got, want := string(encoded), `<FraudFilter mode="PASSIVE"></FraudFilter>`; got != want {
+		t.Errorf("FraudFilter = %v, want %v", got, want)
+	}
+}
+
+func TestFraudResponse_Passive(t *testing.T) {
+	fraud := &FraudResponse{Mode: FraudFilterPassive, Result: FraudHold, Rules: []FraudRuleResult{{Action: FraudHold}, {Action: FraudBlock}}}
+	if fraud.Held() || fraud.Blocked() {
+		t.Errorf("FraudResponse Held = %v, Blocked = %v, want false, false in passive mode", fraud.Held(), fraud.Blocked())
+	}
+
+	response := &ServiceResponse{FraudResponse: fraud}
+	if response.FraudHeldOrBlocked() {
+		t.Errorf("Response FraudHeldOrBlocked = true in passive mode")
+	}
+}
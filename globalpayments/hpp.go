@@ -0,0 +1,296 @@
+package globalpayments
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+//HPPVersion version of the Hosted Payment Page the request is built for
+const HPPVersion = "2"
+
+//HPPRequest request struct for the Hosted Payment Page. It is signed with HostedPaymentService.Sign and then either passed
+//to the HPP JavaScript library as JSON or posted to the HPP URL as a form.
+type HPPRequest struct {
+	MerchantID          string `json:"MERCHANT_ID"`
+	Account             string `json:"ACCOUNT,omitempty"`
+	OrderID             string `json:"ORDER_ID"`
+	Amount              string `json:"AMOUNT"`
+	Currency            string `json:"CURRENCY"`
+	Timestamp           string `json:"TIMESTAMP"`
+	AutoSettleFlag      string `json:"AUTO_SETTLE_FLAG"`
+	Comment1            string `json:"COMMENT1,omitempty"`
+	Comment2            string `json:"COMMENT2,omitempty"`
+	Language            string `json:"HPP_LANG,omitempty"`
+	MerchantResponseURL string `json:"MERCHANT_RESPONSE_URL,omitempty"`
+	HPPVersion          string `json:"HPP_VERSION,omitempty"`
+	PostDimensions      string `json:"HPP_POST_DIMENSIONS,omitempty"`
+	PostResponse        string `json:"HPP_POST_RESPONSE,omitempty"`
+	CardStorageEnable   string `json:"CARD_STORAGE_ENABLE,omitempty"`
+	OfferSaveCard       string `json:"OFFER_SAVE_CARD,omitempty"`
+	PayerExist          string `json:"PAYER_EXIST,omitempty"`
+	PayerRef            string `json:"PAYER_REF,omitempty"`
+	PaymentRef          string `json:"PMT_REF,omitempty"`
+	SelectStoredCard    string `json:"HPP_SELECT_STORED_CARD,omitempty"`
+	CustomerEmail       string `json:"HPP_CUSTOMER_EMAIL,omitempty"`
+	CustomerMobilePhone string `json:"HPP_CUSTOMER_PHONENUMBER_MOBILE,omitempty"`
+	CustomerHomePhone   string `json:"HPP_CUSTOMER_PHONENUMBER_HOME,omitempty"`
+	CustomerWorkPhone   string `json:"HPP_CUSTOMER_PHONENUMBER_WORK,omitempty"`
+	BillingStreet1      string `json:"HPP_BILLING_STREET1,omitempty"`
+	BillingStreet2      string `json:"HPP_BILLING_STREET2,omitempty"`
+	BillingStreet3      string `json:"HPP_BILLING_STREET3,omitempty"`
+	BillingCity         string `json:"HPP_BILLING_CITY,omitempty"`
+	BillingState        string `json:"HPP_BILLING_STATE,omitempty"`
+	BillingPostalCode   string `json:"HPP_BILLING_POSTALCODE,omitempty"`
+	BillingCountry      string `json:"HPP_BILLING_COUNTRY,omitempty"`
+	ShippingStreet1     string `json:"HPP_SHIPPING_STREET1,omitempty"`
+	ShippingStreet2     string `json:"HPP_SHIPPING_STREET2,omitempty"`
+	ShippingStreet3     string `json:"HPP_SHIPPING_STREET3,omitempty"`
+	ShippingCity        string `json:"HPP_SHIPPING_CITY,omitempty"`
+	ShippingState       string `json:"HPP_SHIPPING_STATE,omitempty"`
+	ShippingPostalCode  string `json:"HPP_SHIPPING_POSTALCODE,omitempty"`
+	ShippingCountry     string `json:"HPP_SHIPPING_COUNTRY,omitempty"`
+	AddressMatch        string `json:"HPP_ADDRESS_MATCH_INDICATOR,omitempty"`
+	ChallengeRequest    string `json:"HPP_CHALLENGE_REQUEST_INDICATOR,omitempty"`
+	Sha1Hash            string `json:"SHA1HASH,omitempty"`
+	Sha256Hash          string `json:"SHA256HASH,omitempty"`
+	serviceAuthenticator
+}
+
+//HPP flag values for CARD_STORAGE_ENABLE, OFFER_SAVE_CARD and PAYER_EXIST
+const (
+	HPPFlagEnabled  = "1"
+	HPPFlagDisabled = "0"
+)
+
+//StoreCard stores the card used for the payment against payerRef with paymentRef in the card storage vault.
+//payerExists is set when payerRef was already created with CardStorageService.CreateCustomer, offerSave lets the
+//customer choose whether the card is saved. Either ref may be left empty for Global Payments to generate one.
+func (request *HPPRequest) StoreCard(payerRef string, paymentRef string, payerExists bool, offerSave bool) {
+	request.CardStorageEnable = HPPFlagEnabled
+	request.PayerRef = payerRef
+	request.PaymentRef = paymentRef
+	request.PayerExist = hppFlag(payerExists)
+	request.OfferSaveCard = hppFlag(offerSave)
+}
+
+//ShowStoredCards shows the cards stored against payerRef on the HPP for the customer to pay with
+func (request *HPPRequest) ShowStoredCards(payerRef string) {
+	request.SelectStoredCard = payerRef
+}
+
+//SetCustomer sets the customer's email and phone numbers used for 3D Secure 2. The HPP takes phone numbers as
+//"callingCode|number", so callingCode is the country calling code without the leading + or 00, e.g. "44".
+func (request *HPPRequest) SetCustomer(email string, callingCode string, phoneNumbers *PhoneNumbers) {
+	request.CustomerEmail = email
+	if phoneNumbers == nil {
+		return
+	}
+	request.CustomerMobilePhone = hppPhoneNumber(callingCode, phoneNumbers.Mobile)
+	request.CustomerHomePhone = hppPhoneNumber(callingCode, phoneNumbers.Home)
+	request.CustomerWorkPhone = hppPhoneNumber(callingCode, phoneNumbers.Work)
+}
+
+//hppPhoneNumber formats number as "callingCode|digits", dropping spaces, dashes and brackets
+func hppPhoneNumber(callingCode string, number string) string {
+	digits := onlyDigits(number)
+	if digits == "" {
+		return ""
+	}
+	return strings.TrimLeft(callingCode, "+0") + "|" + digits
+}
+
+//SetAddresses sets the billing and shipping addresses used for 3D Secure 2, with countries converted to their ISO 3166
+//numeric codes. US and Canadian addresses must have a county, sent as its ISO 3166-2 subdivision code, other counties are
+//free-form and left out. Both addresses are checked before any field is set. The address match indicator is only set when
+//both addresses are given.
+func (request *HPPRequest) SetAddresses(billing *Address, shipping *Address) error {
+	billingFields, err := newHPPAddress("billing", billing)
+	if err != nil {
+		return err
+	}
+	shippingFields, err := newHPPAddress("shipping", shipping)
+	if err != nil {
+		return err
+	}
+	if billingFields != nil {
+		request.BillingStreet1, request.BillingStreet2, request.BillingStreet3 = billingFields.street1, billingFields.street2, billingFields.street3
+		request.BillingCity, request.BillingState, request.BillingPostalCode = billingFields.city, billingFields.state, billingFields.postalCode
+		request.BillingCountry = billingFields.country
+	}
+	if shippingFields != nil {
+		request.ShippingStreet1, request.ShippingStreet2, request.ShippingStreet3 = shippingFields.street1, shippingFields.street2, shippingFields.street3
+		request.ShippingCity, request.ShippingState, request.ShippingPostalCode = shippingFields.city, shippingFields.state, shippingFields.postalCode
+		request.ShippingCountry = shippingFields.country
+	}
+	request.AddressMatch = ""
+	if billingFields != nil && shippingFields != nil {
+		request.AddressMatch = "FALSE"
+		if *billingFields == *shippingFields {
+			request.AddressMatch = "TRUE"
+		}
+	}
+	return nil
+}
+
+//hppAddress address as sent in the HPP billing and shipping fields
+type hppAddress struct {
+	street1, street2, street3 string
+	city, state, postalCode   string
+	country                   string
+}
+
+func newHPPAddress(field string, address *Address) (*hppAddress, error) {
+	if address == nil {
+		return nil, nil
+	}
+	country, err := hppCountry(address.Country)
+	if err != nil {
+		return nil, err
+	}
+	state, err := hppSubdivision(field, address.Country, address.County)
+	if err != nil {
+		return nil, err
+	}
+	return &hppAddress{
+		street1:    address.Line1,
+		street2:    address.Line2,
+		street3:    address.Line3,
+		city:       address.City,
+		state:      state,
+		postalCode: address.PostCode,
+		country:    country,
+	}, nil
+}
+
+func hppCountry(country *Country) (string, error) {
+	if country == nil {
+		return "", nil
+	}
+	return country.NumericCode()
+}
+
+//hppStateCountries countries whose addresses must send the state, as the subdivision part of its ISO 3166-2 code
+var hppStateCountries = map[string]bool{"US": true, "CA": true}
+
+//hppSubdivision formats county as the subdivision part of its ISO 3166-2 code, so "us-il" and "IL" are both sent as "IL".
+//The state is only sent for the countries that require it, counties of other countries are free-form and left out.
+func hppSubdivision(field string, country *Country, county string) (string, error) {
+	if country == nil || !hppStateCountries[strings.ToUpper(country.Code)] {
+		return "", nil
+	}
+	subdivision := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(county)), strings.ToUpper(country.Code)+"-")
+	if subdivision == "" {
+		return "", &FieldError{Field: field + ".county", Message: fmt.Sprintf("is required for country %v", country.Code)}
+	}
+	if len(subdivision) != 2 || strings.TrimLeft(subdivision, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") != "" {
+		return "", &FieldError{Field: field + ".county", Message: fmt.Sprintf("%v is not an ISO 3166-2 subdivision code", county)}
+	}
+	return subdivision, nil
+}
+
+func hppFlag(enabled bool) string {
+	if enabled {
+		return HPPFlagEnabled
+	}
+	return HPPFlagDisabled
+}
+
+//HostedPaymentService Hosted Payment Page lets customers enter their card data on a page served by Global Payments, so the
+//merchant's servers never handle it. The service signs HPP requests with the client's credentials.
+type HostedPaymentService struct {
+	service
+	//SHA256 signs requests with SHA256HASH rather than SHA1HASH
+	SHA256 bool
+}
+
+//HostedPaymentServiceAPI interface contain all request types that are allowed within this service for mocking on upstream consumers
+type HostedPaymentServiceAPI interface {
+	Sign(request *HPPRequest) error
+	URL() string
+	ParseResponse(r *http.Request) (*HPPResponse, error)
+	ResponseHandler(handle func(w http.ResponseWriter, r *http.Request, response *HPPResponse, err error)) http.Handler
+	SignCardUpdate(request *HPPCardUpdateRequest) error
+	CardUpdateURL() string
+	ParseCardUpdateResponse(r *http.Request) (*HPPCardUpdateResponse, error)
+}
+
+//URL the signed request is posted to
+func (hpp *HostedPaymentService) URL() string {
+	return hpp.client.HPPURL.String()
+}
+
+func (hpp *HostedPaymentService) signWith(authenticator *serviceAuthenticator, sha256Hash bool) (string, string, error) {
+	authenticator.sharedSecret = hpp.client.HashSecret
+	if sha256Hash {
+		authenticator.hashFunc = sha256.New
+	}
+	signature, err := authenticator.buildSignature()
+	if err != nil {
+		return "", "", err
+	}
+	if sha256Hash {
+		return "", signature, nil
+	}
+	return signature, "", nil
+}
+
+func (hpp *HostedPaymentService) sign(authenticator *serviceAuthenticator) (sha1Hash string, sha256Hash string, err error) {
+	return hpp.signWith(authenticator, hpp.SHA256)
+}
+
+//Sign checks the comments, sets the merchant ID, timestamp and HPP version on the request and signs it with the client's
+//shared secret
+func (hpp *HostedPaymentService) Sign(request *HPPRequest) error {
+	if err := request.validate(); err != nil {
+		return err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = hpp.client.MerchantID
+	if request.HPPVersion == "" {
+		request.HPPVersion = HPPVersion
+	}
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.Amount, request.Currency}
+	if request.SelectStoredCard != "" {
+		request.elementsToHash = append(request.elementsToHash, request.SelectStoredCard, request.PaymentRef)
+	} else if request.CardStorageEnable == HPPFlagEnabled {
+		request.elementsToHash = append(request.elementsToHash, request.PayerRef, request.PaymentRef)
+	}
+	sha1Hash, sha256Hash, err := hpp.sign(&request.serviceAuthenticator)
+	if err != nil {
+		return err
+	}
+	request.Sha1Hash, request.Sha256Hash = sha1Hash, sha256Hash
+	return nil
+}
+
+//JSON returns the signed request as the JSON object expected by the HPP JavaScript library
+func (request *HPPRequest) JSON() ([]byte, error) {
+	return json.Marshal(request)
+}
+
+//Form returns the signed request as the form values to post to the HPP URL
+func (request *HPPRequest) Form() (url.Values, error) {
+	return hppForm(request)
+}
+
+//hppForm all HPP fields are strings named by their json tags, so the form is built from the JSON encoding
+func hppForm(request interface{}) (url.Values, error) {
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	err = json.Unmarshal(encoded, &fields)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	for name, value := range fields {
+		form.Set(name, value)
+	}
+	return form, nil
+}
@@ -0,0 +1,195 @@
+package globalpayments
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newHPPRequest() *HPPRequest {
+	return &HPPRequest{
+		Account:             "internet",
+		OrderID:             "N6qsk4kYRZihmPrTXWYS6g",
+		Amount:              "1999",
+		Currency:            "EUR",
+		AutoSettleFlag:      "1",
+		MerchantResponseURL: "https://merchant.example.com/hpp/response",
+		PostDimensions:      "https://merchant.example.com",
+		PostResponse:        "https://merchant.example.com",
+	}
+}
+
+func TestHostedPaymentService_Sign(t *testing.T) {
+	client, _ := NewClient()
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+
+	request := newHPPRequest()
+	err := client.HPP.Sign(request)
+	if err != nil {
+		t.Errorf("Error signing HPP request: %v", err)
+	}
+
+	json, _ := request.JSON()
+	expectedJSON := `{"MERCHANT_ID":"realexsandbox","ACCOUNT":"internet","ORDER_ID":"N6qsk4kYRZihmPrTXWYS6g","AMOUNT":"1999","CURRENCY":"EUR","TIMESTAMP":"20180614095000","AUTO_SETTLE_FLAG":"1","MERCHANT_RESPONSE_URL":"https://merchant.example.com/hpp/response","HPP_VERSION":"2","HPP_POST_DIMENSIONS":"https://merchant.example.com","HPP_POST_RESPONSE":"https://merchant.example.com","SHA1HASH":"dcd69ee1818f8ac79f00add3179e8d74d2444b96"}`
+	if got := string(json); got != expectedJSON {
+		t.Errorf("HPP request JSON = %v, want %v", got, expectedJSON)
+	}
+
+	form, _ := request.Form()
+	expectedForm := url.Values{
+		"MERCHANT_ID":           {"realexsandbox"},
+		"ACCOUNT":               {"internet"},
+		"ORDER_ID":              {"N6qsk4kYRZihmPrTXWYS6g"},
+		"AMOUNT":                {"1999"},
+		"CURRENCY":              {"EUR"},
+		"TIMESTAMP":             {"20180614095000"},
+		"AUTO_SETTLE_FLAG":      {"1"},
+		"MERCHANT_RESPONSE_URL": {"https://merchant.example.com/hpp/response"},
+		"HPP_VERSION":           {"2"},
+		"HPP_POST_DIMENSIONS":   {"https://merchant.example.com"},
+		"HPP_POST_RESPONSE":     {"https://merchant.example.com"},
+		"SHA1HASH":              {"dcd69ee1818f8ac79f00add3179e8d74d2444b96"},
+	}
+	if !reflect.DeepEqual(form, expectedForm) {
+		t.Errorf("HPP request Form = %v, want %v", form, expectedForm)
+	}
+
+	if got, want := client.HPP.URL(), DefaultHPPURL; got != want {
+		t.Errorf("HPP URL = %v, want %v", got, want)
+	}
+}
+
+func TestHostedPaymentService_Sign_SHA256(t *testing.T) {
+	sha256 := func(client *Client) {
+		client.HPP.SHA256 = true
+	}
+	client, _ := NewClient(sha256)
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+
+	request := newHPPRequest()
+	err := client.HPP.Sign(request)
+	if err != nil {
+		t.Errorf("Error signing HPP request: %v", err)
+	}
+
+	if got, want := request.Sha256Hash, "52c95708069d56d25e5be8341639a535bbeb9d980cc48ca1e98b72e0b0bb2b85"; got != want {
+		t.Errorf("HPP request SHA256HASH = %v, want %v", got, want)
+	}
+
+	if request.Sha1Hash != "" {
+		t.Errorf("HPP request SHA1HASH = %v, want it omitted when signing with SHA256", request.Sha1Hash)
+	}
+}
+
+func TestHostedPaymentService_Sign_CardStorage(t *testing.T) {
+	client, _ := NewClient()
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+
+	request := newHPPRequest()
+	request.StoreCard("03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a", true, false)
+	err := client.HPP.Sign(request)
+	if err != nil {
+		t.Errorf("Error signing HPP request: %v", err)
+	}
+
+	form, _ := request.Form()
+	for name, want := range map[string]string{
+		"CARD_STORAGE_ENABLE": "1",
+		"OFFER_SAVE_CARD":     "0",
+		"PAYER_EXIST":         "1",
+		"PAYER_REF":           "03e28f0e-492e-80bd-20ec318e9334",
+		"PMT_REF":             "3c4af936-483e-a393-f558bec2fb2a",
+		"SHA1HASH":            "2689005cc446aef9f10cb03cb758f8e6c9583690",
+	} {
+		if got := form.Get(name); got != want {
+			t.Errorf("HPP request %v = %v, want %v", name, got, want)
+		}
+	}
+
+	request = newHPPRequest()
+	request.ShowStoredCards("03e28f0e-492e-80bd-20ec318e9334")
+	err = client.HPP.Sign(request)
+	if err != nil {
+		t.Errorf("Error signing HPP request: %v", err)
+	}
+
+	if got, want := request.Sha1Hash, "bad2621c2f49c64bc9c1bb5238f179182dfbec5b"; got != want {
+		t.Errorf("HPP request SHA1HASH = %v, want %v", got, want)
+	}
+}
+
+func TestHPPRequest_SetCustomerAndAddresses(t *testing.T) {
+	request := newHPPRequest()
+	request.SetCustomer("james.mason@example.com", "+44", &PhoneNumbers{Mobile: "07123 456-789", Home: "(020) 7946 0000"})
+	request.ChallengeRequest = ChallengeNoPreference
+
+	billing := &Address{Line1: "Flat 123", Line2: "House 456", City: "Halifax", PostCode: "W5 9HR", Country: &Country{Code: "GB"}}
+	err := request.SetAddresses(billing, nil)
+	if err != nil {
+		t.Errorf("Error setting HPP addresses: %v", err)
+	}
+
+	form, _ := request.Form()
+	for name, want := range map[string]string{
+		"HPP_CUSTOMER_EMAIL":              "james.mason@example.com",
+		"HPP_CUSTOMER_PHONENUMBER_MOBILE": "44|07123456789",
+		"HPP_CUSTOMER_PHONENUMBER_HOME":   "44|02079460000",
+		"HPP_CUSTOMER_PHONENUMBER_WORK":   "",
+		"HPP_BILLING_STREET1":             "Flat 123",
+		"HPP_BILLING_STREET2":             "House 456",
+		"HPP_BILLING_CITY":                "Halifax",
+		"HPP_BILLING_POSTALCODE":          "W5 9HR",
+		"HPP_BILLING_COUNTRY":             "826",
+		"HPP_SHIPPING_COUNTRY":            "",
+		"HPP_ADDRESS_MATCH_INDICATOR":     "",
+		"HPP_CHALLENGE_REQUEST_INDICATOR": "NO_PREFERENCE",
+	} {
+		if got := form.Get(name); got != want {
+			t.Errorf("HPP request %v = %v, want %v", name, got, want)
+		}
+	}
+
+	shipping := &Address{Line1: "Apartment 852", City: "Chicago", County: "us-il", PostCode: "50001", Country: &Country{Code: "US"}}
+	err = request.SetAddresses(billing, shipping)
+	if err != nil {
+		t.Errorf("Error setting HPP addresses: %v", err)
+	}
+	if request.ShippingCountry != "840" || request.ShippingState != "IL" || request.AddressMatch != "FALSE" {
+		t.Errorf("HPP request shipping address = %v %v %v, want 840 IL FALSE", request.ShippingCountry, request.ShippingState, request.AddressMatch)
+	}
+
+	err = request.SetAddresses(billing, billing)
+	if err != nil {
+		t.Errorf("Error setting HPP addresses: %v", err)
+	}
+	if request.AddressMatch != "TRUE" {
+		t.Errorf("HPP request AddressMatch = %v, want TRUE for the same addresses", request.AddressMatch)
+	}
+
+	request = newHPPRequest()
+	if err := request.SetAddresses(billing, &Address{Country: &Country{Code: "XX"}}); err == nil {
+		t.Errorf("HPP request SetAddresses returned no error for unknown country")
+	}
+	if err := request.SetAddresses(billing, &Address{County: "Illinois", Country: &Country{Code: "US"}}); err == nil {
+		t.Errorf("HPP request SetAddresses returned no error for a US state that is not a subdivision code")
+	}
+	if err := request.SetAddresses(billing, &Address{Country: &Country{Code: "CA"}}); err == nil {
+		t.Errorf("HPP request SetAddresses returned no error for a Canadian address without a province")
+	}
+	if request.BillingStreet1 != "" || request.AddressMatch != "" {
+		t.Errorf("HPP request billing address set to %v %v by SetAddresses with an invalid shipping address", request.BillingStreet1, request.AddressMatch)
+	}
+
+	for _, address := range []*Address{
+		{Line1: "Flat 123", County: "West Yorkshire", Country: &Country{Code: "GB"}},
+		{Line1: "Apartment 852", County: "Co. Dublin", Country: &Country{Code: "IE"}},
+	} {
+		if err := request.SetAddresses(address, nil); err != nil {
+			t.Errorf("Error setting HPP address in %v: %v", address.Country.Code, err)
+		}
+		if request.BillingStreet1 != address.Line1 || request.BillingState != "" {
+			t.Errorf("HPP request billing address = %v %v, want %v without a state", request.BillingStreet1, request.BillingState, address.Line1)
+		}
+	}
+}
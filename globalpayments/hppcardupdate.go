@@ -0,0 +1,136 @@
+package globalpayments
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+//HPPCardUpdateRequest request struct for the HPP card update page, where a customer replaces the details of a card already
+//stored against a payer, for example when it has expired. PayerRef and PaymentRef are the refs used by
+//CardStorageService.EditCard and DeleteCard.
+type HPPCardUpdateRequest struct {
+	MerchantID          string `json:"MERCHANT_ID"`
+	Account             string `json:"ACCOUNT,omitempty"`
+	PayerRef            string `json:"PAYER_REF"`
+	PaymentRef          string `json:"PMT_REF"`
+	Timestamp           string `json:"TIMESTAMP"`
+	PayerExist          string `json:"PAYER_EXIST"`
+	Language            string `json:"HPP_LANG,omitempty"`
+	MerchantResponseURL string `json:"MERCHANT_RESPONSE_URL,omitempty"`
+	HPPVersion          string `json:"HPP_VERSION,omitempty"`
+	PostDimensions      string `json:"HPP_POST_DIMENSIONS,omitempty"`
+	PostResponse        string `json:"HPP_POST_RESPONSE,omitempty"`
+	Sha1Hash            string `json:"SHA1HASH,omitempty"`
+	Sha256Hash          string `json:"SHA256HASH,omitempty"`
+	serviceAuthenticator
+}
+
+//HPPCardUpdateResponse response struct for the result posted to the MERCHANT_RESPONSE_URL after a card update. The card
+//number is never returned, SavedPaymentDigits holds its masked digits.
+type HPPCardUpdateResponse struct {
+	Timestamp           string
+	MerchantID          string
+	Account             string
+	PayerRef            string
+	PaymentRef          string
+	Result              string
+	Message             string
+	SavedPaymentType    string
+	SavedPaymentDigits  string
+	SavedPaymentExpDate string
+	SavedPaymentName    string
+	Sha1Hash            string
+	Sha256Hash          string
+	Fields              map[string]string
+}
+
+func (response *HPPCardUpdateResponse) hppResponseFields() map[string]*string {
+	return map[string]*string{
+		"TIMESTAMP":         &response.Timestamp,
+		"MERCHANT_ID":       &response.MerchantID,
+		"ACCOUNT":           &response.Account,
+		"PAYER_REF":         &response.PayerRef,
+		"PMT_REF":           &response.PaymentRef,
+		"RESULT":            &response.Result,
+		"MESSAGE":           &response.Message,
+		"SAVED_PMT_TYPE":    &response.SavedPaymentType,
+		"SAVED_PMT_DIGITS":  &response.SavedPaymentDigits,
+		"SAVED_PMT_EXPDATE": &response.SavedPaymentExpDate,
+		"SAVED_PMT_NAME":    &response.SavedPaymentName,
+		"SHA1HASH":          &response.Sha1Hash,
+		"SHA256HASH":        &response.Sha256Hash,
+	}
+}
+
+//Updated reports whether the stored card was replaced
+func (response *HPPCardUpdateResponse) Updated() bool {
+	return ResultCode(response.Result).IsApproved()
+}
+
+//StoredCard returns the updated card with the refs used by CardStorageService, or nil if the card was not updated
+func (response *HPPCardUpdateResponse) StoredCard() *Card {
+	if !response.Updated() {
+		return nil
+	}
+	return &Card{
+		Ref:            response.PaymentRef,
+		PayerRef:       response.PayerRef,
+		ExpDate:        response.SavedPaymentExpDate,
+		CardHolderName: response.SavedPaymentName,
+		Type:           response.SavedPaymentType,
+	}
+}
+
+//CardUpdateURL the signed card update request is posted to
+func (hpp *HostedPaymentService) CardUpdateURL() string {
+	return hpp.client.HPPCardUpdateURL.String()
+}
+
+//SignCardUpdate sets the merchant ID, timestamp and HPP version on the card update request and signs it with the client's
+//shared secret
+func (hpp *HostedPaymentService) SignCardUpdate(request *HPPCardUpdateRequest) error {
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = hpp.client.MerchantID
+	request.PayerExist = HPPFlagEnabled
+	if request.HPPVersion == "" {
+		request.HPPVersion = HPPVersion
+	}
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.PayerRef, request.PaymentRef}
+	sha1Hash, sha256Hash, err := hpp.sign(&request.serviceAuthenticator)
+	if err != nil {
+		return err
+	}
+	request.Sha1Hash, request.Sha256Hash = sha1Hash, sha256Hash
+	return nil
+}
+
+//JSON returns the signed card update request as the JSON object expected by the HPP JavaScript library
+func (request *HPPCardUpdateRequest) JSON() ([]byte, error) {
+	return json.Marshal(request)
+}
+
+//Form returns the signed card update request as the form values to post to the card update URL
+func (request *HPPCardUpdateRequest) Form() (url.Values, error) {
+	return hppForm(request)
+}
+
+//ParseCardUpdateResponse decodes the card update response posted to the MERCHANT_RESPONSE_URL and verifies its hash
+func (hpp *HostedPaymentService) ParseCardUpdateResponse(r *http.Request) (*HPPCardUpdateResponse, error) {
+	fields, err := parseHPPFields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HPPCardUpdateResponse{}
+	response.Fields = setHPPFields(fields, response.hppResponseFields())
+	valid, err := hpp.validHash([]string{response.Timestamp, response.MerchantID, response.PayerRef, response.PaymentRef,
+		response.Result, response.Message}, response.Sha1Hash, response.Sha256Hash)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, &HPPValidationError{PaymentRef: response.PaymentRef}
+	}
+	return response, nil
+}
@@ -0,0 +1,79 @@
+package globalpayments
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHostedPaymentService_SignCardUpdate(t *testing.T) {
+	client, _ := NewClient()
+	Now = func() time.Time { return time.Unix(1528969800, 0) }
+
+	request := &HPPCardUpdateRequest{
+		Account:             "internet",
+		PayerRef:            "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentRef:          "3c4af936-483e-a393-f558bec2fb2a",
+		MerchantResponseURL: "https://merchant.example.com/hpp/card-update",
+	}
+	err := client.HPP.SignCardUpdate(request)
+	if err != nil {
+		t.Errorf("Error signing HPP card update request: %v", err)
+	}
+
+	form, _ := request.Form()
+	expectedForm := url.Values{
+		"MERCHANT_ID":           {"realexsandbox"},
+		"ACCOUNT":               {"internet"},
+		"PAYER_REF":             {"03e28f0e-492e-80bd-20ec318e9334"},
+		"PMT_REF":               {"3c4af936-483e-a393-f558bec2fb2a"},
+		"TIMESTAMP":             {"20180614095000"},
+		"PAYER_EXIST":           {"1"},
+		"MERCHANT_RESPONSE_URL": {"https://merchant.example.com/hpp/card-update"},
+		"HPP_VERSION":           {"2"},
+		"SHA1HASH":              {"d7e40911e66a48d58957cab767aae26b716437b1"},
+	}
+	if !reflect.DeepEqual(form, expectedForm) {
+		t.Errorf("HPP card update request Form = %v, want %v", form, expectedForm)
+	}
+
+	if got, want := client.HPP.CardUpdateURL(), DefaultHPPCardUpdateURL; got != want {
+		t.Errorf("HPP card update URL = %v, want %v", got, want)
+	}
+}
+
+func TestHostedPaymentService_ParseCardUpdateResponse(t *testing.T) {
+	client, _ := NewClient()
+
+	form := url.Values{
+		"TIMESTAMP":         {"20180614095500"},
+		"MERCHANT_ID":       {"realexsandbox"},
+		"ACCOUNT":           {"internet"},
+		"PAYER_REF":         {"03e28f0e-492e-80bd-20ec318e9334"},
+		"PMT_REF":           {"3c4af936-483e-a393-f558bec2fb2a"},
+		"RESULT":            {"00"},
+		"MESSAGE":           {"Successful"},
+		"SAVED_PMT_TYPE":    {"VISA"},
+		"SAVED_PMT_DIGITS":  {"426397xxxx5262"},
+		"SAVED_PMT_EXPDATE": {"0528"},
+		"SAVED_PMT_NAME":    {"James Mason"},
+		"SHA1HASH":          {"659314bd59f4120576bb1b719b6457159ee3c4f8"},
+	}
+
+	response, err := client.HPP.ParseCardUpdateResponse(newHPPResponseRequest(form))
+	if err != nil {
+		t.Errorf("Error parsing HPP card update response: %v", err)
+	}
+
+	expectedCard := &Card{Ref: "3c4af936-483e-a393-f558bec2fb2a", PayerRef: "03e28f0e-492e-80bd-20ec318e9334", ExpDate: "0528", CardHolderName: "James Mason", Type: "VISA"}
+	if !reflect.DeepEqual(response.StoredCard(), expectedCard) {
+		t.Errorf("HPP card update StoredCard = %v, want %v", response.StoredCard(), expectedCard)
+	}
+
+	form.Set("PMT_REF", "a1d83ea0-9d1b-4b6f-8cb8-7a4e3b2e8f0a")
+	_, err = client.HPP.ParseCardUpdateResponse(newHPPResponseRequest(form))
+	if _, ok := err.(*HPPValidationError); !ok {
+		t.Errorf("HPP card update response error = %v, want *HPPValidationError", err)
+	}
+}
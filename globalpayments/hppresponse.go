@@ -0,0 +1,198 @@
+package globalpayments
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+//HPPValidationError for HPP responses whose SHA1HASH or SHA256HASH does not match the response, for example because it was
+//not posted by Global Payments
+type HPPValidationError struct {
+	OrderID    string
+	PaymentRef string
+}
+
+func (err *HPPValidationError) Error() string {
+	if err.OrderID == "" {
+		return fmt.Sprintf("HPP Validation Hash Error: payment ref: %v", err.PaymentRef)
+	}
+	return fmt.Sprintf("HPP Validation Hash Error: order id: %v", err.OrderID)
+}
+
+//HPPResponse response struct for the result posted to the MERCHANT_RESPONSE_URL after a hosted payment. The embedded
+//ServiceResponse holds the result fields shared with the XML API, Fields holds any custom fields sent with the request.
+type HPPResponse struct {
+	ServiceResponse
+	Amount     string
+	Sha256Hash string
+	HPPCardStorage
+	Fields map[string]string
+}
+
+//HPPCardStorage response fields for the payer and card saved by a card storage HPP request
+type HPPCardStorage struct {
+	RealWalletChosen    string
+	PayerSetup          string
+	PayerSetupMessage   string
+	SavedPayerRef       string
+	PaymentSetup        string
+	PaymentSetupMessage string
+	SavedPaymentType    string
+	SavedPaymentRef     string
+	SavedPaymentDigits  string
+	SavedPaymentExpDate string
+	SavedPaymentName    string
+}
+
+//PayerSaved reports whether a new payer was created for SavedPayerRef
+func (storage *HPPCardStorage) PayerSaved() bool {
+	return storage.PayerSetup == "00"
+}
+
+//CardSaved reports whether the card was stored with SavedPaymentRef
+func (storage *HPPCardStorage) CardSaved() bool {
+	return storage.PaymentSetup == "00"
+}
+
+//StoredCard returns the saved card refs for use with CardStorageService, or nil if no card was saved. The card number is
+//never returned by the HPP, SavedPaymentDigits holds its masked digits.
+func (storage *HPPCardStorage) StoredCard() *Card {
+	if !storage.CardSaved() {
+		return nil
+	}
+	return &Card{
+		Ref:            storage.SavedPaymentRef,
+		PayerRef:       storage.SavedPayerRef,
+		ExpDate:        storage.SavedPaymentExpDate,
+		CardHolderName: storage.SavedPaymentName,
+		Type:           storage.SavedPaymentType,
+	}
+}
+
+//hppResponseFields maps HPP response field names onto the response struct
+func (response *HPPResponse) hppResponseFields() map[string]*string {
+	return map[string]*string{
+		"MERCHANT_ID":       &response.MerchantID,
+		"ACCOUNT":           &response.Account,
+		"ORDER_ID":          &response.OrderID,
+		"TIMESTAMP":         &response.Timestamp,
+		"AMOUNT":            &response.Amount,
+		"AUTHCODE":          &response.AuthCode,
+		"RESULT":            &response.Result,
+		"MESSAGE":           &response.Message,
+		"PASREF":            &response.PasRef,
+		"AVSPOSTCODERESULT": &response.AVSPostcodeResponse,
+		"AVSADDRESSRESULT":  &response.AVSAddressResponse,
+		"CVNRESULT":         &response.CVNResult,
+		"BATCHID":           &response.BatchID,
+		"SRD":               &response.SRD,
+		"REALWALLET_CHOSEN": &response.RealWalletChosen,
+		"PAYER_SETUP":       &response.PayerSetup,
+		"PAYER_SETUP_MSG":   &response.PayerSetupMessage,
+		"SAVED_PAYER_REF":   &response.SavedPayerRef,
+		"PMT_SETUP":         &response.PaymentSetup,
+		"PMT_SETUP_MSG":     &response.PaymentSetupMessage,
+		"SAVED_PMT_TYPE":    &response.SavedPaymentType,
+		"SAVED_PMT_REF":     &response.SavedPaymentRef,
+		"SAVED_PMT_DIGITS":  &response.SavedPaymentDigits,
+		"SAVED_PMT_EXPDATE": &response.SavedPaymentExpDate,
+		"SAVED_PMT_NAME":    &response.SavedPaymentName,
+		"SHA1HASH":          &response.Sha1Hash,
+		"SHA256HASH":        &response.Sha256Hash,
+	}
+}
+
+func newHPPResponse(fields map[string]string) *HPPResponse {
+	response := &HPPResponse{}
+	response.Fields = setHPPFields(fields, response.hppResponseFields())
+	return response
+}
+
+//setHPPFields sets the known fields and returns the remaining custom fields
+func setHPPFields(fields map[string]string, known map[string]*string) map[string]string {
+	custom := map[string]string{}
+	for name, value := range fields {
+		if field, ok := known[name]; ok {
+			*field = value
+			continue
+		}
+		custom[name] = value
+	}
+	return custom
+}
+
+//decodeHPPResponseJSON the HPP JavaScript library posts the response as JSON with every value base64 encoded
+func decodeHPPResponseJSON(hppResponse string) (map[string]string, error) {
+	encoded := map[string]string{}
+	err := json.Unmarshal([]byte(hppResponse), &encoded)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	for name, value := range encoded {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("HPP response field %v: %v", name, err)
+		}
+		fields[name] = string(decoded)
+	}
+	return fields, nil
+}
+
+//validHash checks the response hash with the client's shared secret, using SHA256HASH when the response carries one
+func (hpp *HostedPaymentService) validHash(elementsToHash []string, responseSha1Hash string, responseSha256Hash string) (bool,
+	error) {
+	authenticator := &serviceAuthenticator{elementsToHash: elementsToHash}
+	sha1Hash, sha256Hash, err := hpp.signWith(authenticator, responseSha256Hash != "")
+	if err != nil {
+		return false, err
+	}
+	return sha1Hash == responseSha1Hash && sha256Hash == responseSha256Hash, nil
+}
+
+//parseHPPFields returns the fields posted by the HPP, either as form fields or as the base64 encoded hppResponse JSON
+func parseHPPFields(r *http.Request) (map[string]string, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	if hppResponse := r.PostForm.Get("hppResponse"); hppResponse != "" {
+		return decodeHPPResponseJSON(hppResponse)
+	}
+	fields := map[string]string{}
+	for name := range r.PostForm {
+		fields[name] = r.PostForm.Get(name)
+	}
+	return fields, nil
+}
+
+//ParseResponse decodes the HPP response posted to the MERCHANT_RESPONSE_URL, either as form fields or as the base64 encoded
+//hppResponse JSON posted by the HPP JavaScript library, and verifies its hash.
+func (hpp *HostedPaymentService) ParseResponse(r *http.Request) (*HPPResponse, error) {
+	fields, err := parseHPPFields(r)
+	if err != nil {
+		return nil, err
+	}
+
+	response := newHPPResponse(fields)
+	valid, err := hpp.validHash([]string{response.Timestamp, response.MerchantID, response.OrderID, response.Result,
+		response.Message, response.PasRef, response.AuthCode}, response.Sha1Hash, response.Sha256Hash)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, &HPPValidationError{OrderID: response.OrderID}
+	}
+	return response, nil
+}
+
+//ResponseHandler returns an http.Handler for the MERCHANT_RESPONSE_URL. Each response is parsed and verified with
+//ParseResponse and passed to handle along with any error, handle writes the page shown to the customer.
+func (hpp *HostedPaymentService) ResponseHandler(handle func(w http.ResponseWriter, r *http.Request, response *HPPResponse, err error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, err := hpp.ParseResponse(r)
+		handle(w, r, response, err)
+	})
+}
@@ -0,0 +1,176 @@
+package globalpayments
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newHPPResponseForm() url.Values {
+	return url.Values{
+		"MERCHANT_ID":       {"realexsandbox"},
+		"ACCOUNT":           {"internet"},
+		"ORDER_ID":          {"N6qsk4kYRZihmPrTXWYS6g"},
+		"TIMESTAMP":         {"20180614095500"},
+		"AMOUNT":            {"1999"},
+		"AUTHCODE":          {"12345"},
+		"RESULT":            {"00"},
+		"MESSAGE":           {"[ test system ] AUTHORISED"},
+		"PASREF":            {"14610544313177922"},
+		"AVSPOSTCODERESULT": {"M"},
+		"AVSADDRESSRESULT":  {"M"},
+		"CVNRESULT":         {"M"},
+		"BATCHID":           {"445196"},
+		"SHA1HASH":          {"a6e89a9dccb1a472371390014c12e33e5304c535"},
+		"CUSTOMER_REF":      {"c8e0e3c1"},
+	}
+}
+
+func newHPPResponseRequest(form url.Values) *http.Request {
+	request := httptest.NewRequest("POST", "/hpp/response", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return request
+}
+
+func TestHostedPaymentService_ParseResponse(t *testing.T) {
+	client, _ := NewClient()
+
+	response, err := client.HPP.ParseResponse(newHPPResponseRequest(newHPPResponseForm()))
+	if err != nil {
+		t.Errorf("Error parsing HPP response: %v", err)
+	}
+
+	expectedResponse := &HPPResponse{
+		ServiceResponse: ServiceResponse{
+			Timestamp:           "20180614095500",
+			MerchantID:          "realexsandbox",
+			Account:             "internet",
+			OrderID:             "N6qsk4kYRZihmPrTXWYS6g",
+			AuthCode:            "12345",
+			Result:              "00",
+			CVNResult:           "M",
+			AVSPostcodeResponse: "M",
+			AVSAddressResponse:  "M",
+			BatchID:             "445196",
+			Message:             "[ test system ] AUTHORISED",
+			PasRef:              "14610544313177922",
+			Sha1Hash:            "a6e89a9dccb1a472371390014c12e33e5304c535",
+		},
+		Amount: "1999",
+		Fields: map[string]string{"CUSTOMER_REF": "c8e0e3c1"},
+	}
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("HPP response = %v, want %v", response, expectedResponse)
+	}
+
+	if !response.CVNMatched() || !response.AVSFullMatch() {
+		t.Errorf("HPP response CVN and AVS checks did not match for %v", response)
+	}
+}
+
+func TestHostedPaymentService_ParseResponse_JSON(t *testing.T) {
+	client, _ := NewClient()
+
+	encoded := map[string]string{}
+	for name := range newHPPResponseForm() {
+		encoded[name] = base64.StdEncoding.EncodeToString([]byte(newHPPResponseForm().Get(name)))
+	}
+	hppResponse, _ := json.Marshal(encoded)
+
+	response, err := client.HPP.ParseResponse(newHPPResponseRequest(url.Values{"hppResponse": {string(hppResponse)}}))
+	if err != nil {
+		t.Errorf("Error parsing HPP response: %v", err)
+	}
+
+	if got, want := response.Message, "[ test system ] AUTHORISED"; got != want {
+		t.Errorf("HPP response Message = %v, want %v", got, want)
+	}
+
+	if got, want := response.Fields["CUSTOMER_REF"], "c8e0e3c1"; got != want {
+		t.Errorf("HPP response CUSTOMER_REF = %v, want %v", got, want)
+	}
+}
+
+func TestHostedPaymentService_ParseResponse_SHA256(t *testing.T) {
+	client, _ := NewClient()
+
+	form := newHPPResponseForm()
+	form.Del("SHA1HASH")
+	form.Set("SHA256HASH", "f7617e27d0b2cbed3265e51132f7546bbce6caf589b56438536c3271ce62d91c")
+
+	_, err := client.HPP.ParseResponse(newHPPResponseRequest(form))
+	if err != nil {
+		t.Errorf("Error parsing HPP response: %v", err)
+	}
+}
+
+func TestHostedPaymentService_ParseResponse_ValidationError(t *testing.T) {
+	client, _ := NewClient()
+
+	form := newHPPResponseForm()
+	form.Set("RESULT", "101")
+
+	_, err := client.HPP.ParseResponse(newHPPResponseRequest(form))
+	if _, ok := err.(*HPPValidationError); !ok {
+		t.Errorf("HPP response error = %v, want *HPPValidationError", err)
+	}
+}
+
+func TestHostedPaymentService_ResponseHandler(t *testing.T) {
+	client, _ := NewClient()
+
+	handler := client.HPP.ResponseHandler(func(w http.ResponseWriter, r *http.Request, response *HPPResponse, err error) {
+		if err != nil {
+			t.Errorf("Error parsing HPP response: %v", err)
+			return
+		}
+		fmt.Fprint(w, response.OrderID)
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newHPPResponseRequest(newHPPResponseForm()))
+	if got, want := recorder.Body.String(), "N6qsk4kYRZihmPrTXWYS6g"; got != want {
+		t.Errorf("HPP response handler body = %v, want %v", got, want)
+	}
+}
+
+func TestHostedPaymentService_ParseResponse_CardStorage(t *testing.T) {
+	client, _ := NewClient()
+
+	form := newHPPResponseForm()
+	form.Set("REALWALLET_CHOSEN", "1")
+	form.Set("PAYER_SETUP", "00")
+	form.Set("PAYER_SETUP_MSG", "Successful")
+	form.Set("SAVED_PAYER_REF", "03e28f0e-492e-80bd-20ec318e9334")
+	form.Set("PMT_SETUP", "00")
+	form.Set("PMT_SETUP_MSG", "Successful")
+	form.Set("SAVED_PMT_TYPE", "VISA")
+	form.Set("SAVED_PMT_REF", "3c4af936-483e-a393-f558bec2fb2a")
+	form.Set("SAVED_PMT_DIGITS", "426397xxxx5262")
+	form.Set("SAVED_PMT_EXPDATE", "0525")
+	form.Set("SAVED_PMT_NAME", "James Mason")
+
+	response, err := client.HPP.ParseResponse(newHPPResponseRequest(form))
+	if err != nil {
+		t.Errorf("Error parsing HPP response: %v", err)
+	}
+
+	if !response.PayerSaved() {
+		t.Errorf("HPP response PayerSaved = false for PAYER_SETUP %v", response.PayerSetup)
+	}
+
+	expectedCard := &Card{Ref: "3c4af936-483e-a393-f558bec2fb2a", PayerRef: "03e28f0e-492e-80bd-20ec318e9334", ExpDate: "0525", CardHolderName: "James Mason", Type: "VISA"}
+	if !reflect.DeepEqual(response.StoredCard(), expectedCard) {
+		t.Errorf("HPP response StoredCard = %v, want %v", response.StoredCard(), expectedCard)
+	}
+
+	if got, want := len(response.Fields), 1; got != want {
+		t.Errorf("HPP response Fields = %v, want only the custom field", response.Fields)
+	}
+}
@@ -0,0 +1,85 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+//Digital wallets accepted by auth-mobile
+const (
+	MobileApplePay  = "apple-pay"
+	MobileGooglePay = "pay-with-google"
+)
+
+//MobileRequest request struct for digital wallet payments. Token is the encrypted payment token produced by the wallet and
+//is sent as is. Apple Pay tokens carry the amount, so Amount is only sent for Google Pay.
+type MobileRequest struct {
+	XMLName           xml.Name          `xml:"request"`
+	Type              string            `xml:"type,attr"`
+	Timestamp         string            `xml:"timestamp,attr"`
+	MerchantID        string            `xml:"merchantid"`
+	Account           string            `xml:"account,omitempty"`
+	OrderID           string            `xml:"orderid"`
+	Amount            *Amount           `xml:"amount,omitempty"`
+	Mobile            string            `xml:"mobile"`
+	Token             string            `xml:"token"`
+	AutoSettle        *AutoSettle       `xml:"autosettle,omitempty"`
+	StoredCredential  *StoredCredential `xml:"storedcredential,omitempty"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+	serviceAuthenticator
+}
+
+//StoreWalletCard returns the card-new request that stores a Google Pay card against payerRef with paymentRef, to send with
+//CardStorageService.Send once the authorization is approved. Only merchants that decrypt the Google Pay token themselves
+//(the DIRECT tokenization type) hold the card number and expiry date to pass as card. With gateway tokenization the token
+//is only decrypted by Global Payments, so the wallet card cannot be stored. Apple Pay cards are bound to the device and
+//cannot be stored either.
+func (request *MobileRequest) StoreWalletCard(payerRef string, paymentRef string, card *Card) (*StoreCardRequest, error) {
+	if request.Mobile != MobileGooglePay {
+		validator := &fieldValidator{}
+		validator.add("mobile", fmt.Sprintf("%v does not allow the card to be stored", request.Mobile))
+		return nil, validator.err()
+	}
+	storeCardRequest := NewStoreCardRequest(request.OrderID, payerRef, paymentRef, card)
+	storeCardRequest.Account = request.Account
+	return storeCardRequest, nil
+}
+
+//used getters for objects used within the hash
+
+func (request MobileRequest) getAmount() string {
+	if request.Amount != nil {
+		return request.Amount.Amount
+	}
+	return ""
+}
+
+func (request MobileRequest) getCurrency() string {
+	if request.Amount != nil {
+		return request.Amount.Currency
+	}
+	return ""
+}
+
+//AuthorizeMobile authorizes a digital wallet payment from an Apple Pay or Google Pay token. The token takes the place of the
+//card number within the hash.
+func (payments *PaymentsService) AuthorizeMobile(request *MobileRequest) (*ServiceResponse, *http.Response,
+	error) {
+	if err := request.validate(); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeMobileAuth
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.Token}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitRequest(request)
+}
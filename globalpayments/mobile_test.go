@@ -0,0 +1,107 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const mobileResponseXMLBody = `<response timestamp="20180731090859">
+								   <merchantid>MerchantId</merchantid>
+								   <account>internet</account>
+								   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+								   <authcode>12345</authcode>
+								   <result>00</result>
+								   <message>[ test system ] AUTHORISED</message>
+								   <pasref>14610544313177922</pasref>
+								   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+								</response>`
+
+func TestPaymentsService_AuthorizeMobile(t *testing.T) {
+	mobileRequest := &MobileRequest{
+		Account:    "internet",
+		OrderID:    "N6qsk4kYRZihmPrTXWYS6g",
+		Mobile:     MobileApplePay,
+		Token:      `{"version":"EC_v1","data":"dvMNzlcy6WNB"}`,
+		AutoSettle: &AutoSettle{Flag: "1"},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="auth-mobile" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>N6qsk4kYRZihmPrTXWYS6g</orderid><mobile>apple-pay</mobile><token>{&#34;version&#34;:&#34;EC_v1&#34;,&#34;data&#34;:&#34;dvMNzlcy6WNB&#34;}</token><autosettle flag="1"></autosettle><sha1hash>f781a4f84138f21b33e8e7206bf1c2f7426c3c84</sha1hash></request>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, mobileResponseXMLBody)
+	})
+
+	_, _, err := client.Payments.AuthorizeMobile(mobileRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	_, err = mobileRequest.StoreWalletCard("03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a", nil)
+	if got, want := fmt.Sprint(fieldErrorFields(err)), "[mobile]"; got != want {
+		t.Errorf("StoreWalletCard error fields = %v for %v, want %v", got, mobileRequest.Mobile, want)
+	}
+}
+
+func TestPaymentsService_AuthorizeMobile_StoreCard(t *testing.T) {
+	mobileRequest := &MobileRequest{
+		Account: "internet",
+		OrderID: "N6qsk4kYRZihmPrTXWYS6g",
+		Amount: &Amount{
+			Amount:   "1001",
+			Currency: "EUR",
+		},
+		Mobile: MobileGooglePay,
+		Token:  `{"signature":"MEUCIQDapDDJyf9lH3ztEWksgAjNe","protocolVersion":"ECv1"}`,
+	}
+	card := &Card{Number: "4263970000005262", ExpDate: "0525", CardHolderName: "James Mason", Type: "VISA"}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	requestXMLBodies := []string{
+		`<request type="auth-mobile" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>N6qsk4kYRZihmPrTXWYS6g</orderid><amount currency="EUR">1001</amount><mobile>pay-with-google</mobile><token>{&#34;signature&#34;:&#34;MEUCIQDapDDJyf9lH3ztEWksgAjNe&#34;,&#34;protocolVersion&#34;:&#34;ECv1&#34;}</token><sha1hash>9727686ca7c1f1c2b9e166e3b4b16b549fa14fb3</sha1hash></request>`,
+		`<request type="card-new" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>N6qsk4kYRZihmPrTXWYS6g</orderid><card><ref>3c4af936-483e-a393-f558bec2fb2a</ref><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type></card><sha1hash>a2bbe6964a91eadb149f345b939c1e58daadd80e</sha1hash></request>`,
+	}
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if len(requestXMLBodies) == 0 {
+			t.Fatalf("Unexpected request %v", string(body))
+		}
+		if !reflect.DeepEqual(string(body), requestXMLBodies[0]) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBodies[0])
+		}
+		requestXMLBodies = requestXMLBodies[1:]
+		fmt.Fprint(w, mobileResponseXMLBody)
+	})
+
+	_, _, err := client.Payments.AuthorizeMobile(mobileRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	storeCardRequest, err := mobileRequest.StoreWalletCard("03e28f0e-492e-80bd-20ec318e9334", "3c4af936-483e-a393-f558bec2fb2a", card)
+	if err != nil {
+		t.Errorf("Error building store card request: %v", err)
+	}
+	if card.Ref != "" || card.PayerRef != "" {
+		t.Errorf("StoreWalletCard changed the card refs to %v %v", card.Ref, card.PayerRef)
+	}
+
+	_, _, err = client.CardStorage.Send(storeCardRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+	if len(requestXMLBodies) != 0 {
+		t.Errorf("%d requests were not sent", len(requestXMLBodies))
+	}
+}
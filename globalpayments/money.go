@@ -0,0 +1,196 @@
+package globalpayments
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//currencyExponents ISO 4217 minor unit exponents of currencies that do not use 2 decimal places
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0,
+	"UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+//currencyCodes active ISO 4217 currency codes, without the precious metal, fund and testing codes
+var currencyCodes = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true, "AWG": true,
+	"AZN": true, "BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true,
+	"BOB": true, "BOV": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true, "BZD": true, "CAD": true,
+	"CDF": true, "CHE": true, "CHF": true, "CHW": true, "CLF": true, "CLP": true, "CNY": true, "COP": true, "COU": true,
+	"CRC": true, "CUC": true, "CUP": true, "CVE": true, "CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true,
+	"EGP": true, "ERN": true, "ETB": true, "EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true,
+	"GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true, "HNL": true, "HTG": true, "HUF": true,
+	"IDR": true, "ILS": true, "INR": true, "IQD": true, "IRR": true, "ISK": true, "JMD": true, "JOD": true, "JPY": true,
+	"KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true, "KWD": true, "KYD": true, "KZT": true,
+	"LAK": true, "LBP": true, "LKR": true, "LRD": true, "LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true,
+	"MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true, "MVR": true, "MWK": true, "MXN": true,
+	"MXV": true, "MYR": true, "MZN": true, "NAD": true, "NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true,
+	"OMR": true, "PAB": true, "PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true,
+	"RON": true, "RSD": true, "RUB": true, "RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true,
+	"SGD": true, "SHP": true, "SLE": true, "SLL": true, "SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true,
+	"SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true, "TND": true, "TOP": true, "TRY": true, "TTD": true,
+	"TWD": true, "TZS": true, "UAH": true, "UGX": true, "USD": true, "USN": true, "UYI": true, "UYU": true, "UYW": true,
+	"UZS": true, "VED": true, "VES": true, "VND": true, "VUV": true, "WST": true, "XAF": true, "XCD": true, "XCG": true,
+	"XOF": true, "XPF": true, "YER": true, "ZAR": true, "ZMW": true, "ZWG": true, "ZWL": true,
+}
+
+//validCurrency checks currency is an active ISO 4217 currency code
+func validCurrency(currency string) error {
+	if !currencyCodes[currency] {
+		return &FieldError{Field: "amount.currency", Message: fmt.Sprintf("%v is not an ISO 4217 currency code", currency)}
+	}
+	return nil
+}
+
+//CurrencyExponent returns the number of decimal places of the currency's minor unit, e.g. 2 for EUR, 0 for JPY and 3 for
+//BHD
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+//Money an amount in the minor units of its currency, e.g. 1999 EUR is 19.99 euro and 1999 JPY is 1999 yen
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+//NewMoney returns minor units of currency
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+//NewMoneyFromFloat converts an amount in major units to money, rounding half away from zero to the currency's minor unit.
+//Rounding is done on the shortest decimal form of major, so 0.285 rounds to 0.29 even though its binary value is below it.
+//NaN, infinite amounts, amounts too large for int64 minor units and unknown currencies are rejected.
+func NewMoneyFromFloat(major float64, currency string) (Money, error) {
+	if err := validCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	if math.IsNaN(major) || math.IsInf(major, 0) {
+		return Money{}, &FieldError{Field: "amount", Message: fmt.Sprintf("%v is not a finite amount", major)}
+	}
+	exponent := CurrencyExponent(currency)
+	decimal := strconv.FormatFloat(math.Abs(major), 'f', -1, 64)
+	whole, fraction := decimal, ""
+	if i := strings.Index(decimal, "."); i >= 0 {
+		whole, fraction = decimal[:i], decimal[i+1:]
+	}
+	fraction += strings.Repeat("0", exponent+1)
+	minor, err := strconv.ParseInt(whole+fraction[:exponent], 10, 64)
+	if err != nil || (fraction[exponent] >= '5' && minor == math.MaxInt64) {
+		return Money{}, &FieldError{Field: "amount", Message: fmt.Sprintf("%v is out of range", major)}
+	}
+	if fraction[exponent] >= '5' {
+		minor++
+	}
+	if major < 0 {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+//ParseMoney parses a decimal amount in major units, such as "19.99", without rounding. Amounts with more decimal places
+//than the currency's minor unit are rejected.
+func ParseMoney(major string, currency string) (Money, error) {
+	if err := validCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	exponent := CurrencyExponent(currency)
+	whole, fraction := major, ""
+	if i := strings.Index(major, "."); i >= 0 {
+		whole, fraction = major[:i], major[i+1:]
+	}
+	if len(fraction) > exponent {
+		return Money{}, &FieldError{Field: "amount", Message: fmt.Sprintf("%v has more than %d decimal places for %v", major, exponent, currency)}
+	}
+	negative := strings.HasPrefix(whole, "-")
+	digits := strings.TrimPrefix(whole, "-") + fraction
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return Money{}, &FieldError{Field: "amount", Message: fmt.Sprintf("%v is not a decimal amount", major)}
+	}
+	minor, err := strconv.ParseInt(digits+strings.Repeat("0", exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, &FieldError{Field: "amount", Message: fmt.Sprintf("%v is out of range", major)}
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+//Format returns the amount in major units with the currency's decimal places, e.g. "19.99"
+func (money Money) Format() string {
+	exponent := CurrencyExponent(money.Currency)
+	magnitude := uint64(money.Minor)
+	sign := ""
+	if money.Minor < 0 {
+		//negated through uint64 so MinInt64, which has no positive int64, is formatted too
+		sign, magnitude = "-", -magnitude
+	}
+	digits := fmt.Sprintf("%0*d", exponent+1, magnitude)
+	if exponent == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+//String returns the amount in major units followed by the currency, e.g. "19.99 EUR"
+func (money Money) String() string {
+	return money.Format() + " " + money.Currency
+}
+
+//Add returns the sum of both amounts, which must be in the same currency. Sums beyond the int64 range return an error.
+func (money Money) Add(other Money) (Money, error) {
+	if money.Currency != other.Currency {
+		return Money{}, fmt.Errorf("cannot add %v to %v", other.Currency, money.Currency)
+	}
+	sum := money.Minor + other.Minor
+	if (other.Minor > 0 && sum < money.Minor) || (other.Minor < 0 && sum > money.Minor) {
+		return Money{}, fmt.Errorf("adding %v to %v is out of range", other, money)
+	}
+	return Money{Minor: sum, Currency: money.Currency}, nil
+}
+
+//Sub returns the difference of both amounts, which must be in the same currency. It is used to find what remains to be
+//captured or refunded after a partial settle or rebate. Differences beyond the int64 range return an error.
+func (money Money) Sub(other Money) (Money, error) {
+	if money.Currency != other.Currency {
+		return Money{}, fmt.Errorf("cannot subtract %v from %v", other.Currency, money.Currency)
+	}
+	difference := money.Minor - other.Minor
+	if (other.Minor > 0 && difference > money.Minor) || (other.Minor < 0 && difference < money.Minor) {
+		return Money{}, fmt.Errorf("subtracting %v from %v is out of range", other, money)
+	}
+	return Money{Minor: difference, Currency: money.Currency}, nil
+}
+
+//IsZero reports whether the amount is zero
+func (money Money) IsZero() bool {
+	return money.Minor == 0
+}
+
+//IsNegative reports whether the amount is below zero
+func (money Money) IsNegative() bool {
+	return money.Minor < 0
+}
+
+//Amount returns the amount for a request
+func (money Money) Amount() *Amount {
+	return &Amount{Amount: strconv.FormatInt(money.Minor, 10), Currency: money.Currency}
+}
+
+//Money returns the amount of a request or response as money
+func (amount *Amount) Money() (Money, error) {
+	minor, err := strconv.ParseInt(amount.Amount, 10, 64)
+	if err != nil {
+		return Money{}, &FieldError{Field: "amount", Message: fmt.Sprintf("%v is not an amount in minor units", amount.Amount)}
+	}
+	return Money{Minor: minor, Currency: amount.Currency}, nil
+}
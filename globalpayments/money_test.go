@@ -0,0 +1,146 @@
+package globalpayments
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		major    string
+		currency string
+		minor    int64
+		format   string
+	}{
+		{"19.99", "EUR", 1999, "19.99"},
+		{"19.9", "EUR", 1990, "19.90"},
+		{"0.05", "GBP", 5, "0.05"},
+		{"1000", "JPY", 1000, "1000"},
+		{"1.234", "BHD", 1234, "1.234"},
+		{"-5", "EUR", -500, "-5.00"},
+	}
+
+	for _, test := range tests {
+		money, err := ParseMoney(test.major, test.currency)
+		if err != nil {
+			t.Errorf("Error parsing %v %v: %v", test.major, test.currency, err)
+		}
+		if money.Minor != test.minor {
+			t.Errorf("ParseMoney(%v, %v) = %v, want %v", test.major, test.currency, money.Minor, test.minor)
+		}
+		if got := money.Format(); got != test.format {
+			t.Errorf("Money %v Format = %v, want %v", money.Minor, got, test.format)
+		}
+	}
+
+	for _, major := range []string{"1000.5", "", "-", "1,000", "abc"} {
+		if _, err := ParseMoney(major, "JPY"); err == nil {
+			t.Errorf("ParseMoney(%v, JPY) returned no error", major)
+		}
+	}
+}
+
+func TestNewMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		major    float64
+		currency string
+		minor    int64
+	}{
+		{19.99, "EUR", 1999},
+		{0.285, "EUR", 29},
+		{1000.4, "JPY", 1000},
+		{1.2345, "BHD", 1235},
+		{-0.285, "EUR", -29},
+	}
+
+	for _, test := range tests {
+		money, err := NewMoneyFromFloat(test.major, test.currency)
+		if err != nil {
+			t.Errorf("Error converting %v %v: %v", test.major, test.currency, err)
+		}
+		if money.Minor != test.minor {
+			t.Errorf("NewMoneyFromFloat(%v, %v) = %v, want %v", test.major, test.currency, money.Minor, test.minor)
+		}
+	}
+
+	invalid := []struct {
+		major    float64
+		currency string
+		field    string
+	}{
+		{math.NaN(), "EUR", "amount"},
+		{math.Inf(1), "EUR", "amount"},
+		{math.Inf(-1), "EUR", "amount"},
+		{1e17, "EUR", "amount"},
+		{-1e20, "JPY", "amount"},
+		{19.99, "XYZ", "amount.currency"},
+		{19.99, "eur", "amount.currency"},
+	}
+
+	for _, test := range invalid {
+		_, err := NewMoneyFromFloat(test.major, test.currency)
+		if fieldError, ok := err.(*FieldError); !ok || fieldError.Field != test.field {
+			t.Errorf("NewMoneyFromFloat(%v, %v) error = %v, want a %v field error", test.major, test.currency, err, test.field)
+		}
+	}
+
+	if _, err := ParseMoney("19.99", "XYZ"); err == nil {
+		t.Errorf("ParseMoney(19.99, XYZ) returned no error")
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	authorized := NewMoney(10000, "EUR")
+	captured := NewMoney(2550, "EUR")
+
+	remaining, err := authorized.Sub(captured)
+	if err != nil {
+		t.Errorf("Error subtracting money: %v", err)
+	}
+	if got, want := remaining.String(), "74.50 EUR"; got != want {
+		t.Errorf("Remaining = %v, want %v", got, want)
+	}
+
+	total, _ := remaining.Add(captured)
+	if total != authorized {
+		t.Errorf("Total = %v, want %v", total, authorized)
+	}
+
+	if _, err := authorized.Add(NewMoney(100, "GBP")); err == nil {
+		t.Errorf("Adding GBP to EUR returned no error")
+	}
+
+	maximum, minimum := NewMoney(math.MaxInt64, "EUR"), NewMoney(math.MinInt64, "EUR")
+	if _, err := maximum.Add(NewMoney(1, "EUR")); err == nil {
+		t.Errorf("Adding to %v returned no error", maximum)
+	}
+	if _, err := minimum.Add(NewMoney(-1, "EUR")); err == nil {
+		t.Errorf("Adding to %v returned no error", minimum)
+	}
+	if _, err := minimum.Sub(NewMoney(1, "EUR")); err == nil {
+		t.Errorf("Subtracting from %v returned no error", minimum)
+	}
+	if _, err := NewMoney(0, "EUR").Sub(minimum); err == nil {
+		t.Errorf("Subtracting %v returned no error", minimum)
+	}
+	if sum, err := maximum.Add(minimum); err != nil || sum.Minor != -1 {
+		t.Errorf("Adding %v to %v = %v, %v, want -0.01 EUR", minimum, maximum, sum, err)
+	}
+	if got, want := minimum.Format(), "-92233720368547758.08"; got != want {
+		t.Errorf("Format = %v, want %v", got, want)
+	}
+}
+
+func TestMoney_Amount(t *testing.T) {
+	amount := NewMoney(1999, "JPY").Amount()
+	expectedAmount := &Amount{Amount: "1999", Currency: "JPY"}
+	if !reflect.DeepEqual(amount, expectedAmount) {
+		t.Errorf("Money Amount = %v, want %v", amount, expectedAmount)
+	}
+
+	money, err := amount.Money()
+	if err != nil || money != NewMoney(1999, "JPY") {
+		t.Errorf("Amount Money = %v, %v, want 1999 JPY", money, err)
+	}
+}
@@ -0,0 +1,97 @@
+package globalpayments
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ECI values for the outcome of a 3D Secure authentication. Mastercard uses its own values, every other scheme uses the Visa
+//values.
+const (
+	ECIVisaAuthenticated          = "05"
+	ECIVisaAttempted              = "06"
+	ECIVisaNotAuthenticated       = "07"
+	ECIMastercardAuthenticated    = "02"
+	ECIMastercardAttempted        = "01"
+	ECIMastercardNotAuthenticated = "00"
+)
+
+//MPI request struct for the results of a 3D Secure authentication, sent on the authorization to shift liability. The
+//authentication can come from this package's 3D Secure services or from a third party 3DS server. CAVV and XID are set for
+//3D Secure 1, AuthenticationValue, DSTransID and MessageVersion for 3D Secure 2.
+type MPI struct {
+	CAVV                string `xml:"cavv,omitempty"`
+	XID                 string `xml:"xid,omitempty"`
+	ECI                 string `xml:"eci,omitempty"`
+	DSTransID           string `xml:"ds_trans_id,omitempty"`
+	AuthenticationValue string `xml:"authentication_value,omitempty"`
+	MessageVersion      string `xml:"message_version,omitempty"`
+}
+
+//eciValues valid ECI values for each card scheme
+var eciValues = map[string][]string{
+	CardTypeVisa:       {ECIVisaAuthenticated, ECIVisaAttempted, ECIVisaNotAuthenticated},
+	CardTypeAmex:       {ECIVisaAuthenticated, ECIVisaAttempted, ECIVisaNotAuthenticated},
+	CardTypeDiners:     {ECIVisaAuthenticated, ECIVisaAttempted, ECIVisaNotAuthenticated},
+	CardTypeJCB:        {ECIVisaAuthenticated, ECIVisaAttempted, ECIVisaNotAuthenticated},
+	CardTypeDiscover:   {ECIVisaAuthenticated, ECIVisaAttempted, ECIVisaNotAuthenticated},
+	CardTypeMastercard: {ECIMastercardAuthenticated, ECIMastercardAttempted, ECIMastercardNotAuthenticated},
+}
+
+//normalizeECI ECI values are returned both with and without a leading zero, for example "5" and "05"
+func normalizeECI(eci string) string {
+	if len(eci) == 1 {
+		return "0" + eci
+	}
+	return eci
+}
+
+//IsThreeDSecure2 reports whether the authentication values come from a 3D Secure 2 authentication
+func (mpi *MPI) IsThreeDSecure2() bool {
+	return strings.HasPrefix(mpi.MessageVersion, "2.")
+}
+
+//validateMPI checks the ECI is valid for the card scheme and that the values the scheme requires for it are present. When
+//the card type is not known, for example for a stored card, the ECI is checked against every scheme.
+func validateMPI(mpi *MPI, cardType string) error {
+	if mpi == nil {
+		return nil
+	}
+
+	eci := normalizeECI(mpi.ECI)
+	schemes := []string{cardType}
+	if cardType == "" {
+		schemes = []string{CardTypeVisa, CardTypeMastercard}
+	}
+
+	valid := false
+	for _, scheme := range schemes {
+		values, ok := eciValues[scheme]
+		if !ok {
+			return &FieldError{Field: "card.type", Message: fmt.Sprintf("3D Secure not supported for card type %q", scheme)}
+		}
+		for _, value := range values {
+			if eci == value {
+				valid = true
+			}
+		}
+	}
+	if !valid {
+		return &FieldError{Field: "mpi.eci", Message: fmt.Sprintf("invalid value %q for card type %q", mpi.ECI, cardType)}
+	}
+
+	if eci == ECIVisaAuthenticated || eci == ECIMastercardAuthenticated {
+		if mpi.IsThreeDSecure2() && mpi.AuthenticationValue == "" {
+			return &FieldError{Field: "mpi.authentication_value", Message: "required for an authenticated 3D Secure 2 transaction"}
+		}
+		if !mpi.IsThreeDSecure2() && mpi.CAVV == "" {
+			return &FieldError{Field: "mpi.cavv", Message: "required for an authenticated 3D Secure 1 transaction"}
+		}
+	}
+
+	if mpi.IsThreeDSecure2() && mpi.DSTransID == "" {
+		return &FieldError{Field: "mpi.ds_trans_id", Message: "required for 3D Secure 2"}
+	}
+
+	return nil
+}
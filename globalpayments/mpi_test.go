@@ -0,0 +1,57 @@
+package globalpayments
+
+import "testing"
+
+func TestMPI_validateMPI(t *testing.T) {
+	cases := []struct {
+		mpi      *MPI
+		cardType string
+		err      string
+	}{
+		{nil, CardTypeVisa, ""},
+		{&MPI{ECI: "05", CAVV: "AAACBllleHchZTBWIGV4AAAAAAA=", XID: "e9dafe706f7142469c45d4877aaf5984"}, CardTypeVisa, ""},
+		{&MPI{ECI: "5", CAVV: "AAACBllleHchZTBWIGV4AAAAAAA=", XID: "e9dafe706f7142469c45d4877aaf5984"}, CardTypeAmex, ""},
+		{&MPI{ECI: "06"}, CardTypeJCB, ""},
+		{&MPI{ECI: "02", DSTransID: "c272b04f-6e7b-43a2-bb78-90f4fb94aa25", AuthenticationValue: "ODQzNjgwNjU0ZjM3N2JmYTg0NTM=", MessageVersion: "2.1.0"}, CardTypeMastercard, ""},
+		{&MPI{ECI: "01", DSTransID: "c272b04f-6e7b-43a2-bb78-90f4fb94aa25", MessageVersion: "2.1.0"}, "", ""},
+		{&MPI{ECI: "05", CAVV: "AAACBllleHchZTBWIGV4AAAAAAA="}, CardTypeMastercard, `Field Error: field: mpi.eci, invalid value "05" for card type "MC"`},
+		{&MPI{ECI: "02", CAVV: "AAACBllleHchZTBWIGV4AAAAAAA="}, CardTypeVisa, `Field Error: field: mpi.eci, invalid value "02" for card type "VISA"`},
+		{&MPI{ECI: "09"}, "", `Field Error: field: mpi.eci, invalid value "09" for card type ""`},
+		{&MPI{ECI: "05"}, CardTypeVisa, "Field Error: field: mpi.cavv, required for an authenticated 3D Secure 1 transaction"},
+		{&MPI{ECI: "05", DSTransID: "c272b04f-6e7b-43a2-bb78-90f4fb94aa25", MessageVersion: "2.2.0"}, CardTypeVisa, "Field Error: field: mpi.authentication_value, required for an authenticated 3D Secure 2 transaction"},
+		{&MPI{ECI: "06", MessageVersion: "2.1.0"}, CardTypeVisa, "Field Error: field: mpi.ds_trans_id, required for 3D Secure 2"},
+		{&MPI{ECI: "05"}, "MAESTRO", `Field Error: field: card.type, 3D Secure not supported for card type "MAESTRO"`},
+	}
+
+	for _, c := range cases {
+		err := validateMPI(c.mpi, c.cardType)
+		if c.err == "" && err != nil {
+			t.Errorf("validateMPI(%v, %v) returned %v, want no error", c.mpi, c.cardType, err)
+		}
+		if c.err != "" && (err == nil || err.Error() != c.err) {
+			t.Errorf("validateMPI(%v, %v) returned %v, want %v", c.mpi, c.cardType, err, c.err)
+		}
+	}
+}
+
+func TestPaymentsService_Authorize_InvalidMPI(t *testing.T) {
+	fixNow(t)
+	client, _ := NewClient()
+
+	authRequest := &PaymentRequest{
+		OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		Amount:  &Amount{Amount: "1001", Currency: "EUR"},
+		Card:    &Card{Number: "5425230000004415", ExpDate: "0525", CardHolderName: "James Mason", Type: CardTypeMastercard},
+		MPI:     &MPI{ECI: "05", CAVV: "AAACBllleHchZTBWIGV4AAAAAAA="},
+	}
+
+	response, httpResponse, err := client.Payments.Authorize(authRequest)
+
+	if got, want := err.Error(), `Field Error: field: mpi.eci, invalid value "05" for card type "MC"`; got != want {
+		t.Errorf("Incorrect Field Error thrown got: %v, want: %v", got, want)
+	}
+
+	if response != nil || httpResponse != nil {
+		t.Errorf("Authorize sent a request with an invalid MPI, got: %v, %v", response, httpResponse)
+	}
+}
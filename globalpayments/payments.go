@@ -0,0 +1,174 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+//PaymentRequest request struct for apis that process raw card data or existing orders rather than stored cards
+type PaymentRequest struct {
+	XMLName           xml.Name          `xml:"request"`
+	Type              string            `xml:"type,attr"`
+	Timestamp         string            `xml:"timestamp,attr"`
+	MerchantID        string            `xml:"merchantid"`
+	Account           string            `xml:"account,omitempty"`
+	Channel           Channel           `xml:"channel,omitempty"`
+	OrderID           string            `xml:"orderid"`
+	PasRef            string            `xml:"pasref,omitempty"`
+	AuthCode          string            `xml:"authcode,omitempty"`
+	PaRes             string            `xml:"pares,omitempty"`
+	Amount            *Amount           `xml:"amount,omitempty"`
+	Card              *Card             `xml:"card,omitempty"`
+	AutoSettle        *AutoSettle       `xml:"autosettle,omitempty"`
+	StoredCredential  *StoredCredential `xml:"storedcredential,omitempty"`
+	Recurring         *Recurring        `xml:"recurring,omitempty"`
+	DCCInfo           *DCCInfo          `xml:"dccinfo,omitempty"`
+	MPI               *MPI              `xml:"mpi,omitempty"`
+	FraudFilter       *FraudFilter      `xml:"fraudfilter,omitempty"`
+	TSSInfo           *TSSInfo          `xml:"tssinfo,omitempty"`
+	Comments          Comments          `xml:"comments,omitempty"`
+	SupplementaryData SupplementaryData `xml:"supplementarydata,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+	serviceAuthenticator
+}
+
+//PaymentsService Payments API offers requests that are processed against card data supplied with the request or against an
+//existing order, rather than a card held in Card Storage.
+type PaymentsService struct {
+	service
+}
+
+//PaymentsServiceAPI interface contain all request types that are allowed within this service for mocking on upstream consumers
+type PaymentsServiceAPI interface {
+	Authorize(request *PaymentRequest) (*ServiceResponse, *http.Response,
+		error)
+	Offline(request *PaymentRequest) (*ServiceResponse, *http.Response,
+		error)
+	Manual(request *PaymentRequest) (*ServiceResponse, *http.Response,
+		error)
+	Validate(request *PaymentRequest) (*ServiceResponse, *http.Response,
+		error)
+	DCCRate(request *PaymentRequest) (*ServiceResponse, *http.Response,
+		error)
+	VerifyEnrolled(request *PaymentRequest) (*ThreeDSecureResponse, *http.Response,
+		error)
+	VerifySig(request *PaymentRequest) (*ThreeDSecureResponse, *http.Response,
+		error)
+	AuthorizeMobile(request *MobileRequest) (*ServiceResponse, *http.Response,
+		error)
+}
+
+//used getters for objects used within the hash
+
+func (request PaymentRequest) getAmount() string {
+	if request.Amount != nil {
+		return request.Amount.Amount
+	}
+	return ""
+}
+
+func (request PaymentRequest) getCurrency() string {
+	if request.Amount != nil {
+		return request.Amount.Currency
+	}
+	return ""
+}
+
+func (request PaymentRequest) getCardNumber() string {
+	if request.Card != nil {
+		return request.Card.Number
+	}
+	return ""
+}
+
+func (request PaymentRequest) getCardType() string {
+	if request.Card != nil {
+		return request.Card.Type
+	}
+	return ""
+}
+
+//Authorize raises an authorization against the card data supplied with the request. When the card is being set up for
+//later merchant initiated charges, send a StoredCredential (or the legacy Recurring flag) so the SRD is returned.
+func (payments *PaymentsService) Authorize(request *PaymentRequest) (*ServiceResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeAuth); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeAuth
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitRequest(request)
+}
+
+//Offline When an authorization is referred ("102" result) the issuer can supply an authorization code over the phone. The offline
+//request pushes the referred transaction through with that code, referencing the original order by its order ID and pasref.
+func (payments *PaymentsService) Offline(request *PaymentRequest) (*ServiceResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeOffline); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeOffline
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitRequest(request)
+}
+
+//Manual request creates a new transaction from card data and an authorization code obtained by voice authorization, without
+//the transaction having been sent to the issuer through Global Payments first.
+func (payments *PaymentsService) Manual(request *PaymentRequest) (*ServiceResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeManual); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeManual
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitRequest(request)
+}
+
+//Validate Open to Buy (OTB) checks that card data supplied with the request is valid and active without processing a payment
+//against it, for example before the card is added to Card Storage. The CVN and AVS results of the check are returned on the
+//response.
+func (payments *PaymentsService) Validate(request *PaymentRequest) (*ServiceResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeOTB); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeOTB
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitRequest(request)
+}
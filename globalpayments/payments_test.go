@@ -0,0 +1,237 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPaymentsService_Offline(t *testing.T) {
+	offlineRequest := &PaymentRequest{
+		Account:  "internet",
+		OrderID:  "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		PasRef:   "14631546336115597",
+		AuthCode: "12345",
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="offline" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid><pasref>14631546336115597</pasref><authcode>12345</authcode><sha1hash>a704ae616d8d1a3d3669d5cd57602321116bc337</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <timetaken>1</timetaken>
+							   <sha1hash>3bfaa6e256dca5e26ab52202682f12810cc2728f</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Payments.Offline(offlineRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedResponse := &ServiceResponse{
+		XMLName:              xml.Name{Local: "response"},
+		Timestamp:            "20180731090859",
+		MerchantID:           "MerchantId",
+		Account:              "internet",
+		OrderID:              "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		Result:               "00",
+		Message:              "[ test system ] AUTHORISED",
+		PasRef:               "14610544313177922",
+		AuthCode:             "12345",
+		TimeTaken:            "1",
+		Sha1Hash:             "3bfaa6e256dca5e26ab52202682f12810cc2728f",
+		serviceAuthenticator: serviceAuthenticator{elementsToHash: []string{"20180731090859", "MerchantId", "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3", "00", "[ test system ] AUTHORISED", "14610544313177922", "12345"}, sharedSecret: "Po8lRRT67a"}}
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("Request Body = %v, want %v", response, expectedResponse)
+	}
+}
+
+func TestPaymentsService_Manual(t *testing.T) {
+	manualRequest := &PaymentRequest{
+		Account:  "internet",
+		Channel:  "MOTO",
+		OrderID:  "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		AuthCode: "12345",
+		Amount: &Amount{
+			Amount:   "1001",
+			Currency: "EUR",
+		},
+		Card: &Card{
+			Number:         "4263970000005262",
+			ExpDate:        "0525",
+			CardHolderName: "James Mason",
+			Type:           "VISA",
+		},
+		AutoSettle: &AutoSettle{Flag: "1"},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="manual" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><channel>MOTO</channel><orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid><authcode>12345</authcode><amount currency="EUR">1001</amount><card><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type></card><autosettle flag="1"></autosettle><sha1hash>30790d243b2ebe75a2c30ce13d15ba846d3cd402</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <timetaken>1</timetaken>
+							   <sha1hash>3bfaa6e256dca5e26ab52202682f12810cc2728f</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Payments.Manual(manualRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if got, want := response.AuthCode, "12345"; got != want {
+		t.Errorf("Response AuthCode = %v, want %v", got, want)
+	}
+
+	if got, want := response.Result, "00"; got != want {
+		t.Errorf("Response Result = %v, want %v", got, want)
+	}
+}
+
+func TestPaymentsService_Validate(t *testing.T) {
+	validateRequest := &PaymentRequest{
+		Account: "internet",
+		OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		Card: &Card{
+			Number:         "4263970000005262",
+			ExpDate:        "0525",
+			CardHolderName: "James Mason",
+			Type:           "VISA",
+			CVN:            &CVN{Number: "123", PresInd: "1"},
+		},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="otb" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid><card><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type><cvn><number>123</number><presind>1</presind></cvn></card><sha1hash>4b8d5225731c9c0fc22211a7d917e19fd6ef08fb</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid>
+							   <result>00</result>
+							   <cvnresult>M</cvnresult>
+							   <avspostcoderesponse>M</avspostcoderesponse>
+							   <avsaddressresponse>N</avsaddressresponse>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <timetaken>1</timetaken>
+							   <sha1hash>ef6b49b8ef6616e30e77bdab555f7da4e8239795</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Payments.Validate(validateRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if !response.CVNMatched() {
+		t.Errorf("Response CVNMatched = false for CVN result %v", response.CVNResult)
+	}
+
+	if response.AVSFullMatch() {
+		t.Error("Response AVSFullMatch = true, want false for a mismatched address")
+	}
+
+	if !response.AVSMismatched() {
+		t.Error("Response AVSMismatched = false, want true for a mismatched address")
+	}
+}
+
+func TestPaymentsService_Authorize(t *testing.T) {
+	authRequest := &PaymentRequest{
+		Account: "internet",
+		OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3",
+		Amount: &Amount{
+			Amount:   "1001",
+			Currency: "EUR",
+		},
+		Card: &Card{
+			Number:         "4263970000005262",
+			ExpDate:        "0525",
+			CardHolderName: "James Mason",
+			Type:           "VISA",
+			CVN:            &CVN{Number: "123", PresInd: "1"},
+		},
+		AutoSettle:       &AutoSettle{Flag: "1"},
+		StoredCredential: FirstStoredCredential(StoredCredentialRecurring),
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="auth" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid><amount currency="EUR">1001</amount><card><number>4263970000005262</number><expdate>0525</expdate><chname>James Mason</chname><type>VISA</type><cvn><number>123</number><presind>1</presind></cvn></card><autosettle flag="1"></autosettle><storedcredential><type>recurring</type><initiator>cardholder</initiator><sequence>first</sequence></storedcredential><sha1hash>30790d243b2ebe75a2c30ce13d15ba846d3cd402</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <timetaken>1</timetaken>
+							   <srd>MMC0F00YE4000000715</srd>
+							   <sha1hash>3bfaa6e256dca5e26ab52202682f12810cc2728f</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Payments.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if got, want := response.SRD, "MMC0F00YE4000000715"; got != want {
+		t.Errorf("Response SRD = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,223 @@
+package globalpayments
+
+//ResultCode result code returned by Global Payments for a request
+type ResultCode string
+
+//Result codes returned by Global Payments. Codes are grouped by their first digit: 1xx are declined by the issuer,
+//2xx are errors with the bank's systems, 3xx are errors with the Global Payments systems and 5xx are invalid requests.
+const (
+	ResultApproved         ResultCode = "00"
+	ResultDeclined         ResultCode = "101"
+	ResultReferralB        ResultCode = "102"
+	ResultReferralA        ResultCode = "103"
+	ResultFraudDeclined    ResultCode = "107"
+	ResultAccountSuspended ResultCode = "666"
+)
+
+var resultDescriptions = map[ResultCode]string{
+	ResultApproved:         "Approved",
+	ResultDeclined:         "Declined by the issuer",
+	ResultReferralB:        "Referral B, declined by the issuer",
+	ResultReferralA:        "Referral A, card reported lost or stolen",
+	ResultFraudDeclined:    "Declined by the fraud checks",
+	ResultAccountSuspended: "Merchant account deactivated",
+}
+
+//IsApproved reports whether the request was successful
+func (result ResultCode) IsApproved() bool {
+	return result == ResultApproved
+}
+
+//IsDeclined reports whether the issuer declined the request
+func (result ResultCode) IsDeclined() bool {
+	return len(result) == 3 && result[0] == '1'
+}
+
+//IsSoftDecline reports whether the request failed for a reason that may clear, a plain decline, a Referral B or an
+//error with the bank's systems, so it can be retried later. Lost or stolen cards and fraud declines are hard declines.
+func (result ResultCode) IsSoftDecline() bool {
+	return result == ResultDeclined || result == ResultReferralB || result.IsBankError()
+}
+
+//IsBankError reports whether the request failed on the bank's systems
+func (result ResultCode) IsBankError() bool {
+	return len(result) == 3 && result[0] == '2'
+}
+
+//IsGatewayError reports whether the request failed on the Global Payments systems
+func (result ResultCode) IsGatewayError() bool {
+	return len(result) == 3 && result[0] == '3'
+}
+
+//IsInvalidRequest reports whether the request was rejected as invalid and should not be retried unchanged
+func (result ResultCode) IsInvalidRequest() bool {
+	return len(result) == 3 && result[0] == '5'
+}
+
+//Description of the result code
+func (result ResultCode) Description() string {
+	if description, ok := resultDescriptions[result]; ok {
+		return description
+	}
+	switch {
+	case result.IsDeclined():
+		return "Declined by the issuer"
+	case result.IsBankError():
+		return "Error with the bank's systems"
+	case result.IsGatewayError():
+		return "Error with the Global Payments systems"
+	case result.IsInvalidRequest():
+		return "Invalid request"
+	}
+	return "Unknown result"
+}
+
+//CheckResult result of a CVN, postcode or address check returned by Global Payments
+type CheckResult string
+
+//CVN and AVS check result codes returned by Global Payments, compared through ServiceResponse.CVN, AVSPostcode and
+//AVSAddress
+const (
+	CheckMatched      CheckResult = "M"
+	CheckNotMatched   CheckResult = "N"
+	CheckNotChecked   CheckResult = "I"
+	CheckNotCertified CheckResult = "U"
+	CheckNotProcessed CheckResult = "P"
+)
+
+var checkDescriptions = map[CheckResult]string{
+	CheckMatched:      "Matched",
+	CheckNotMatched:   "Not matched",
+	CheckNotChecked:   "Not checked",
+	CheckNotCertified: "Issuer not certified",
+	CheckNotProcessed: "Not processed",
+}
+
+//Matched reports whether the issuer matched the value sent with the request
+func (check CheckResult) Matched() bool {
+	return check == CheckMatched
+}
+
+//Mismatched reports whether the issuer checked the value sent with the request and it did not match
+func (check CheckResult) Mismatched() bool {
+	return check == CheckNotMatched
+}
+
+//Checked reports whether the issuer checked the value sent with the request, whether it matched or not
+func (check CheckResult) Checked() bool {
+	return check.Matched() || check.Mismatched()
+}
+
+//Description of the check result. An empty result means no value was sent to check.
+func (check CheckResult) Description() string {
+	if check == "" {
+		return "Not sent"
+	}
+	if description, ok := checkDescriptions[check]; ok {
+		return description
+	}
+	return "Unknown result"
+}
+
+//ResultCode typed result code of the response
+func (response *ServiceResponse) ResultCode() ResultCode {
+	return ResultCode(response.Result)
+}
+
+//CVN typed result of the CVN check
+func (response *ServiceResponse) CVN() CheckResult {
+	return CheckResult(response.CVNResult)
+}
+
+//AVSPostcode typed result of the AVS postcode check
+func (response *ServiceResponse) AVSPostcode() CheckResult {
+	return CheckResult(response.AVSPostcodeResponse)
+}
+
+//AVSAddress typed result of the AVS address check
+func (response *ServiceResponse) AVSAddress() CheckResult {
+	return CheckResult(response.AVSAddressResponse)
+}
+
+//IsApproved reports whether the request was successful
+func (response *ServiceResponse) IsApproved() bool {
+	return response.ResultCode().IsApproved()
+}
+
+//IsSoftDecline reports whether the request was declined for a reason that may clear, so it can be retried later
+func (response *ServiceResponse) IsSoftDecline() bool {
+	return response.ResultCode().IsSoftDecline()
+}
+
+//Decision what to do with an approved authorization
+type Decision string
+
+//Decisions returned by AuthorizationPolicy.Decide
+const (
+	DecisionNone   Decision = ""
+	DecisionAccept Decision = "accept"
+	DecisionReview Decision = "review"
+	DecisionVoid   Decision = "void"
+)
+
+//severity orders decisions so the strictest one found on a response wins
+func (decision Decision) severity() int {
+	switch decision {
+	case DecisionReview:
+		return 1
+	case DecisionVoid:
+		return 2
+	}
+	return 0
+}
+
+//AuthorizationPolicy decides whether to accept, review or void an approved stored-card authorization from its CVN and
+//AVS results. A Decision left empty accepts the authorization.
+type AuthorizationPolicy struct {
+	//CVNMismatch when the issuer did not match the CVN
+	CVNMismatch Decision
+	//CVNNotChecked when the CVN was not sent or not checked by the issuer
+	CVNNotChecked Decision
+	//AVSMismatch when the issuer did not match the postcode or the address
+	AVSMismatch Decision
+	//AVSNotChecked when neither check failed but the postcode or the address was not sent or not checked by the issuer
+	AVSNotChecked Decision
+}
+
+//NewAuthorizationPolicy policy that voids CVN mismatches, reviews AVS mismatches and accepts cards that were not checked,
+//as stored-card authorizations are often sent without the CVN
+func NewAuthorizationPolicy() *AuthorizationPolicy {
+	return &AuthorizationPolicy{
+		CVNMismatch:   DecisionVoid,
+		CVNNotChecked: DecisionAccept,
+		AVSMismatch:   DecisionReview,
+		AVSNotChecked: DecisionAccept,
+	}
+}
+
+//Decide returns the strictest decision that applies to the response, or DecisionNone when it was not approved and there
+//is no authorization to act on
+func (policy *AuthorizationPolicy) Decide(response *ServiceResponse) Decision {
+	if !response.IsApproved() {
+		return DecisionNone
+	}
+	decision := DecisionAccept
+	apply := func(candidate Decision) {
+		if candidate.severity() > decision.severity() {
+			decision = candidate
+		}
+	}
+	switch {
+	case response.CVNMismatched():
+		apply(policy.CVNMismatch)
+	case !response.CVN().Checked():
+		apply(policy.CVNNotChecked)
+	}
+	switch {
+	case response.AVSMismatched():
+		apply(policy.AVSMismatch)
+	case !response.AVSFullMatch():
+		apply(policy.AVSNotChecked)
+	}
+	return decision
+}
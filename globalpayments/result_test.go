@@ -0,0 +1,77 @@
+package globalpayments
+
+import "testing"
+
+func TestResultCode(t *testing.T) {
+	cases := []struct {
+		result                ResultCode
+		approved, softDecline bool
+		declined              bool
+		description           string
+	}{
+		{"00", true, false, false, "Approved"},
+		{"101", false, true, true, "Declined by the issuer"},
+		{"102", false, true, true, "Referral B, declined by the issuer"},
+		{"103", false, false, true, "Referral A, card reported lost or stolen"},
+		{"107", false, false, true, "Declined by the fraud checks"},
+		{"205", false, true, false, "Error with the bank's systems"},
+		{"301", false, false, false, "Error with the Global Payments systems"},
+		{"508", false, false, false, "Invalid request"},
+		{"", false, false, false, "Unknown result"},
+	}
+
+	for _, c := range cases {
+		if got := c.result.IsApproved(); got != c.approved {
+			t.Errorf("IsApproved for %q is %v, want %v", c.result, got, c.approved)
+		}
+		if got := c.result.IsSoftDecline(); got != c.softDecline {
+			t.Errorf("IsSoftDecline for %q is %v, want %v", c.result, got, c.softDecline)
+		}
+		if got := c.result.IsDeclined(); got != c.declined {
+			t.Errorf("IsDeclined for %q is %v, want %v", c.result, got, c.declined)
+		}
+		if got := c.result.Description(); got != c.description {
+			t.Errorf("Description for %q is %v, want %v", c.result, got, c.description)
+		}
+	}
+}
+
+func TestCheckResult(t *testing.T) {
+	response := &ServiceResponse{CVNResult: "M", AVSPostcodeResponse: "N", AVSAddressResponse: "U"}
+	if got, want := response.CVN(), CheckMatched; got != want {
+		t.Errorf("CVN = %v, want %v", got, want)
+	}
+	if !response.AVSPostcode().Checked() || response.AVSAddress().Checked() {
+		t.Errorf("Checked for %v/%v = %v/%v, want true/false", response.AVSPostcodeResponse, response.AVSAddressResponse, response.AVSPostcode().Checked(), response.AVSAddress().Checked())
+	}
+	for check, want := range map[CheckResult]string{CheckNotCertified: "Issuer not certified", "": "Not sent", "X": "Unknown result"} {
+		if got := check.Description(); got != want {
+			t.Errorf("Description for %q is %v, want %v", check, got, want)
+		}
+	}
+}
+
+func TestAuthorizationPolicy_Decide(t *testing.T) {
+	cases := []struct {
+		response *ServiceResponse
+		decision Decision
+	}{
+		{&ServiceResponse{Result: "00", CVNResult: "M", AVSPostcodeResponse: "M", AVSAddressResponse: "M"}, DecisionAccept},
+		{&ServiceResponse{Result: "00"}, DecisionAccept},
+		{&ServiceResponse{Result: "00", CVNResult: "M", AVSPostcodeResponse: "M", AVSAddressResponse: "N"}, DecisionReview},
+		{&ServiceResponse{Result: "00", CVNResult: "N", AVSPostcodeResponse: "N", AVSAddressResponse: "N"}, DecisionVoid},
+		{&ServiceResponse{Result: "101", CVNResult: "N"}, DecisionNone},
+	}
+
+	policy := NewAuthorizationPolicy()
+	for _, c := range cases {
+		if got := policy.Decide(c.response); got != c.decision {
+			t.Errorf("Decide for %v/%v/%v/%v is %q, want %q", c.response.Result, c.response.CVNResult, c.response.AVSPostcodeResponse, c.response.AVSAddressResponse, got, c.decision)
+		}
+	}
+
+	strict := &AuthorizationPolicy{CVNNotChecked: DecisionReview, AVSNotChecked: DecisionVoid}
+	if got, want := strict.Decide(&ServiceResponse{Result: "00", CVNResult: "U", AVSPostcodeResponse: "M"}), DecisionVoid; got != want {
+		t.Errorf("Decide with strict policy is %q, want %q", got, want)
+	}
+}
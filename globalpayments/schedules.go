@@ -0,0 +1,187 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+//ScheduleFrequency describes how often a schedule raises a transaction
+type ScheduleFrequency string
+
+//Schedule frequencies accepted by Global Payments
+const (
+	ScheduleDaily        ScheduleFrequency = "daily"
+	ScheduleWeekly       ScheduleFrequency = "weekly"
+	ScheduleBiWeekly     ScheduleFrequency = "biweekly"
+	ScheduleSemiMonthly  ScheduleFrequency = "semimonthly"
+	ScheduleMonthly      ScheduleFrequency = "monthly"
+	ScheduleBiMonthly    ScheduleFrequency = "bimonthly"
+	ScheduleQuarterly    ScheduleFrequency = "quarterly"
+	ScheduleSemiAnnually ScheduleFrequency = "semiannually"
+	ScheduleAnnually     ScheduleFrequency = "annually"
+)
+
+//ScheduleIndefinitely number of times for a schedule that runs until it is deleted
+const ScheduleIndefinitely = -1
+
+//ScheduleRequest request struct for all schedule apis
+type ScheduleRequest struct {
+	XMLName           xml.Name          `xml:"request"`
+	Type              string            `xml:"type,attr"`
+	Timestamp         string            `xml:"timestamp,attr"`
+	MerchantID        string            `xml:"merchantid"`
+	Account           string            `xml:"account,omitempty"`
+	ScheduleRef       string            `xml:"scheduleref,omitempty"`
+	Alias             string            `xml:"alias,omitempty"`
+	OrderIDStub       string            `xml:"orderidstub,omitempty"`
+	TransType         string            `xml:"transtype,omitempty"`
+	Frequency         ScheduleFrequency `xml:"schedule,omitempty"`
+	StartDate         string            `xml:"startdate,omitempty"`
+	NumTimes          int               `xml:"numtimes,omitempty"`
+	EndDate           string            `xml:"enddate,omitempty"`
+	PayerRef          string            `xml:"payerref,omitempty"`
+	PaymentMethod     string            `xml:"paymentmethod,omitempty"`
+	Amount            *Amount           `xml:"amount,omitempty"`
+	ProductID         string            `xml:"prodid,omitempty"`
+	VariableReference string            `xml:"varref,omitempty"`
+	CustomerNumber    string            `xml:"custno,omitempty"`
+	Comment           string            `xml:"comment,omitempty"`
+	Sha1Hash          string            `xml:"sha1hash"`
+	serviceAuthenticator
+}
+
+//Schedule response struct
+type Schedule struct {
+	ScheduleRef       string            `xml:"scheduleref"`
+	Alias             string            `xml:"alias"`
+	OrderIDStub       string            `xml:"orderidstub"`
+	TransType         string            `xml:"transtype"`
+	Frequency         ScheduleFrequency `xml:"schedule"`
+	StartDate         string            `xml:"startdate"`
+	EndDate           string            `xml:"enddate"`
+	NumTimes          int               `xml:"numtimes"`
+	TimesRun          int               `xml:"timesrun"`
+	PayerRef          string            `xml:"payerref"`
+	PaymentMethod     string            `xml:"paymentmethod"`
+	Amount            *Amount           `xml:"amount"`
+	ProductID         string            `xml:"prodid"`
+	VariableReference string            `xml:"varref"`
+	CustomerNumber    string            `xml:"custno"`
+	Comment           string            `xml:"comment"`
+	ScheduleText      string            `xml:"scheduletext"`
+}
+
+//ScheduleResponse response struct for schedule apis. Schedule is populated by Get and Schedules by Search.
+type ScheduleResponse struct {
+	XMLName xml.Name `xml:"response"`
+	ServiceResponse
+	Schedule
+	Schedules []Schedule `xml:"schedules>schedule"`
+}
+
+//SchedulesService Schedules API lets Global Payments raise transactions against a stored card on a recurring schedule,
+//rather than each charge being sent as an authorization.
+type SchedulesService struct {
+	service
+}
+
+//SchedulesServiceAPI interface contain all request types that are allowed within this service for mocking on upstream consumers
+type SchedulesServiceAPI interface {
+	Create(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+		error)
+	Get(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+		error)
+	Delete(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+		error)
+	Search(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+		error)
+}
+
+//used getters for objects used within the hash
+
+func (request ScheduleRequest) getAmount() string {
+	if request.Amount != nil {
+		return request.Amount.Amount
+	}
+	return ""
+}
+
+func (request ScheduleRequest) getCurrency() string {
+	if request.Amount != nil {
+		return request.Amount.Currency
+	}
+	return ""
+}
+
+func (schedules *SchedulesService) transmitScheduleRequest(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+	error) {
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+
+	response := &ScheduleResponse{}
+	httpResponse, err := schedules.transmit(request, response)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	return response, httpResponse, nil
+}
+
+//Create sets up a schedule against a stored payer and card. Global Payments raises a transaction of the given amount at
+//each frequency interval from the start date until it has run the number of times requested, or until the end date.
+func (schedules *SchedulesService) Create(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+	error) {
+	if err := request.validate(requestTypeScheduleNew); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = schedules.client.MerchantID
+	request.Type = requestTypeScheduleNew
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.ScheduleRef, request.getAmount(), request.getCurrency(), request.PayerRef, string(request.Frequency)}
+	request.sharedSecret = schedules.client.HashSecret
+	return schedules.transmitScheduleRequest(request)
+}
+
+//Get retrieves the details of an existing schedule by its schedule reference.
+func (schedules *SchedulesService) Get(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+	error) {
+	if err := request.validate(requestTypeScheduleGet); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = schedules.client.MerchantID
+	request.Type = requestTypeScheduleGet
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.ScheduleRef}
+	request.sharedSecret = schedules.client.HashSecret
+	return schedules.transmitScheduleRequest(request)
+}
+
+//Delete stops an existing schedule so no further transactions are raised against it.
+func (schedules *SchedulesService) Delete(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+	error) {
+	if err := request.validate(requestTypeScheduleDelete); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = schedules.client.MerchantID
+	request.Type = requestTypeScheduleDelete
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.ScheduleRef}
+	request.sharedSecret = schedules.client.HashSecret
+	return schedules.transmitScheduleRequest(request)
+}
+
+//Search returns the schedules set up against a stored payer, optionally narrowed to a single payment method.
+func (schedules *SchedulesService) Search(request *ScheduleRequest) (*ScheduleResponse, *http.Response,
+	error) {
+	if err := request.validate(requestTypeScheduleSearch); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = schedules.client.MerchantID
+	request.Type = requestTypeScheduleSearch
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.PayerRef, request.PaymentMethod}
+	request.sharedSecret = schedules.client.HashSecret
+	return schedules.transmitScheduleRequest(request)
+}
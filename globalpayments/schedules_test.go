@@ -0,0 +1,208 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSchedulesService_Create(t *testing.T) {
+	scheduleRequest := &ScheduleRequest{
+		Account:       "internet",
+		ScheduleRef:   "ScheduleRef1",
+		Alias:         "Gold Membership",
+		OrderIDStub:   "gold",
+		TransType:     "auth",
+		Frequency:     ScheduleMonthly,
+		StartDate:     "20180701",
+		NumTimes:      12,
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "999",
+			Currency: "EUR",
+		},
+		ProductID:      "gold-monthly",
+		CustomerNumber: "E8953893489",
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="schedule-new" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><scheduleref>ScheduleRef1</scheduleref><alias>Gold Membership</alias><orderidstub>gold</orderidstub><transtype>auth</transtype><schedule>monthly</schedule><startdate>20180701</startdate><numtimes>12</numtimes><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><amount currency="EUR">999</amount><prodid>gold-monthly</prodid><custno>E8953893489</custno><sha1hash>f83315990e646dd0f6d618a4f0f8eecb358cacbb</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <result>00</result>
+							   <message>Schedule created successfully</message>
+							   <sha1hash>2bc073d04274b6c773fb2023ad6b435e778d2317</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Schedules.Create(scheduleRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedResponse := &ScheduleResponse{
+		XMLName: xml.Name{Local: "response"},
+		ServiceResponse: ServiceResponse{
+			Timestamp:            "20180731090859",
+			MerchantID:           "MerchantId",
+			Account:              "internet",
+			Result:               "00",
+			Message:              "Schedule created successfully",
+			Sha1Hash:             "2bc073d04274b6c773fb2023ad6b435e778d2317",
+			serviceAuthenticator: serviceAuthenticator{elementsToHash: []string{"20180731090859", "MerchantId", "", "00", "Schedule created successfully", "", ""}, sharedSecret: "Po8lRRT67a"}}}
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("Response = %v, want %v", response, expectedResponse)
+	}
+}
+
+func TestSchedulesService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="schedule-get" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><scheduleref>ScheduleRef1</scheduleref><sha1hash>3471e62fc9ef7f25749390f95c5bdb3f9c9a037f</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <result>00</result>
+							   <message>Schedule retrieved</message>
+							   <scheduleref>ScheduleRef1</scheduleref>
+							   <alias>Gold Membership</alias>
+							   <orderidstub>gold</orderidstub>
+							   <transtype>auth</transtype>
+							   <schedule>monthly</schedule>
+							   <startdate>20180701</startdate>
+							   <enddate></enddate>
+							   <numtimes>12</numtimes>
+							   <timesrun>3</timesrun>
+							   <payerref>03e28f0e-492e-80bd-20ec318e9334</payerref>
+							   <paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod>
+							   <amount currency="EUR">999</amount>
+							   <prodid>gold-monthly</prodid>
+							   <varref></varref>
+							   <custno>E8953893489</custno>
+							   <comment></comment>
+							   <scheduletext>Monthly on the 1st</scheduletext>
+							   <sha1hash>ca81bc98f6734c7f9e1fb86844a9b0bc3b9e172e</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Schedules.Get(&ScheduleRequest{Account: "internet", ScheduleRef: "ScheduleRef1"})
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedSchedule := Schedule{
+		ScheduleRef:    "ScheduleRef1",
+		Alias:          "Gold Membership",
+		OrderIDStub:    "gold",
+		TransType:      "auth",
+		Frequency:      ScheduleMonthly,
+		StartDate:      "20180701",
+		NumTimes:       12,
+		TimesRun:       3,
+		PayerRef:       "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod:  "3c4af936-483e-a393-f558bec2fb2a",
+		Amount:         &Amount{Amount: "999", Currency: "EUR"},
+		ProductID:      "gold-monthly",
+		CustomerNumber: "E8953893489",
+		ScheduleText:   "Monthly on the 1st",
+	}
+	if !reflect.DeepEqual(response.Schedule, expectedSchedule) {
+		t.Errorf("Response Schedule = %v, want %v", response.Schedule, expectedSchedule)
+	}
+}
+
+func TestSchedulesService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="schedule-delete" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><scheduleref>ScheduleRef1</scheduleref><sha1hash>3471e62fc9ef7f25749390f95c5bdb3f9c9a037f</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <result>00</result>
+							   <message>Schedule retrieved</message>
+							   <sha1hash>invalid</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Schedules.Delete(&ScheduleRequest{ScheduleRef: "ScheduleRef1"})
+
+	if got, want := err.Error(), "Validation Hash Error: method: POST, path: /epage-remote.cgi, status code:200"; got != want {
+		t.Errorf("Incorrect Validation Error thrown got: %v, want: %v", got, want)
+	}
+
+	if response != nil {
+		t.Errorf("Response supposed to be nil, got: %v", response)
+	}
+}
+
+func TestSchedulesService_Search(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="schedule-search" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><sha1hash>21fc054610ee8c36fa8c7af87e1d07dd5bf113e2</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <result>00</result>
+							   <message>Schedules found</message>
+							   <schedules>
+								  <schedule>
+									 <scheduleref>ScheduleRef1</scheduleref>
+									 <schedule>monthly</schedule>
+									 <numtimes>12</numtimes>
+									 <amount currency="EUR">999</amount>
+								  </schedule>
+								  <schedule>
+									 <scheduleref>ScheduleRef2</scheduleref>
+									 <schedule>annually</schedule>
+									 <numtimes>-1</numtimes>
+									 <amount currency="EUR">9999</amount>
+								  </schedule>
+							   </schedules>
+							   <sha1hash>842ee6bbe658b83f669496145c8f378bd582926a</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Schedules.Search(&ScheduleRequest{PayerRef: "03e28f0e-492e-80bd-20ec318e9334"})
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedSchedules := []Schedule{
+		{ScheduleRef: "ScheduleRef1", Frequency: ScheduleMonthly, NumTimes: 12, Amount: &Amount{Amount: "999", Currency: "EUR"}},
+		{ScheduleRef: "ScheduleRef2", Frequency: ScheduleAnnually, NumTimes: ScheduleIndefinitely, Amount: &Amount{Amount: "9999", Currency: "EUR"}},
+	}
+	if !reflect.DeepEqual(response.Schedules, expectedSchedules) {
+		t.Errorf("Response Schedules = %v, want %v", response.Schedules, expectedSchedules)
+	}
+}
@@ -0,0 +1,138 @@
+package globalpayments
+
+import "fmt"
+
+//StoredCredentialType describes the agreement under which a stored card is charged
+type StoredCredentialType string
+
+//StoredCredentialInitiator describes who initiated a transaction against a stored card
+type StoredCredentialInitiator string
+
+//StoredCredentialSequence describes where a transaction sits within a series of stored card transactions
+type StoredCredentialSequence string
+
+//Stored credential values accepted by Global Payments
+const (
+	StoredCredentialOneOff      StoredCredentialType = "oneoff"
+	StoredCredentialInstallment StoredCredentialType = "installment"
+	StoredCredentialRecurring   StoredCredentialType = "recurring"
+
+	InitiatorCardholder StoredCredentialInitiator = "cardholder"
+	InitiatorMerchant   StoredCredentialInitiator = "merchant"
+
+	SequenceFirst      StoredCredentialSequence = "first"
+	SequenceSubsequent StoredCredentialSequence = "subsequent"
+)
+
+//StoredCredential request struct for card-on-file transactions. SRD is the scheme reference data returned on the
+//response of the first transaction in the series and must be sent on every subsequent merchant initiated transaction.
+type StoredCredential struct {
+	Type      StoredCredentialType      `xml:"type"`
+	Initiator StoredCredentialInitiator `xml:"initiator"`
+	Sequence  StoredCredentialSequence  `xml:"sequence"`
+	SRD       string                    `xml:"srd,omitempty"`
+}
+
+//FirstStoredCredential returns the stored credential for the first, cardholder initiated, transaction of a series. The SRD
+//returned on its response is carried into later transactions with ServiceResponse.SubsequentStoredCredential.
+func FirstStoredCredential(credentialType StoredCredentialType) *StoredCredential {
+	return &StoredCredential{Type: credentialType, Initiator: InitiatorCardholder, Sequence: SequenceFirst}
+}
+
+//MerchantInitiatedStoredCredential returns the stored credential for a subsequent, merchant initiated, transaction of a series
+//using the scheme reference data of the first transaction.
+func MerchantInitiatedStoredCredential(credentialType StoredCredentialType, srd string) *StoredCredential {
+	return &StoredCredential{Type: credentialType, Initiator: InitiatorMerchant, Sequence: SequenceSubsequent, SRD: srd}
+}
+
+//SubsequentStoredCredential returns the stored credential for a merchant initiated transaction following the transaction
+//this response belongs to, carrying its scheme reference data. If the response had no SRD the stored credential is
+//rejected when the transaction is validated.
+func (response *ServiceResponse) SubsequentStoredCredential(credentialType StoredCredentialType) *StoredCredential {
+	return MerchantInitiatedStoredCredential(credentialType, response.SRD)
+}
+
+func (storedCredential *StoredCredential) validate() error {
+	switch storedCredential.Type {
+	case StoredCredentialOneOff, StoredCredentialInstallment, StoredCredentialRecurring:
+	default:
+		return &FieldError{Field: "storedcredential.type", Message: fmt.Sprintf("invalid value %q", storedCredential.Type)}
+	}
+	switch storedCredential.Initiator {
+	case InitiatorCardholder, InitiatorMerchant:
+	default:
+		return &FieldError{Field: "storedcredential.initiator", Message: fmt.Sprintf("invalid value %q", storedCredential.Initiator)}
+	}
+	switch storedCredential.Sequence {
+	case SequenceFirst, SequenceSubsequent:
+	default:
+		return &FieldError{Field: "storedcredential.sequence", Message: fmt.Sprintf("invalid value %q", storedCredential.Sequence)}
+	}
+	if storedCredential.Initiator == InitiatorMerchant && storedCredential.Sequence == SequenceSubsequent && storedCredential.SRD == "" {
+		return &FieldError{Field: "storedcredential.srd", Message: "required for a subsequent merchant initiated transaction"}
+	}
+	return nil
+}
+
+func validateStoredCredential(storedCredential *StoredCredential) error {
+	if storedCredential == nil {
+		return nil
+	}
+	return storedCredential.validate()
+}
+
+//RecurringType describes whether the amount of a legacy recurring transaction is fixed or variable
+type RecurringType string
+
+//RecurringSequence describes where a legacy recurring transaction sits within its series
+type RecurringSequence string
+
+//Legacy recurring values accepted by Global Payments
+const (
+	RecurringFixed    RecurringType = "fixed"
+	RecurringVariable RecurringType = "variable"
+
+	RecurringFirst      RecurringSequence = "first"
+	RecurringSubsequent RecurringSequence = "subsequent"
+	RecurringLast       RecurringSequence = "last"
+)
+
+//Recurring request struct for the legacy recurring flag still required by some acquirers. It predates stored credentials
+//and describes the same series, so a request may carry either a Recurring or a StoredCredential but never both.
+type Recurring struct {
+	Type     RecurringType     `xml:"type,attr"`
+	Sequence RecurringSequence `xml:"sequence,attr"`
+	Flag     string            `xml:"flag,attr"`
+}
+
+//NewRecurring returns a legacy recurring flag for the given type and sequence
+func NewRecurring(recurringType RecurringType, sequence RecurringSequence) *Recurring {
+	return &Recurring{Type: recurringType, Sequence: sequence, Flag: "1"}
+}
+
+func (recurring *Recurring) validate() error {
+	switch recurring.Type {
+	case RecurringFixed, RecurringVariable:
+	default:
+		return &FieldError{Field: "recurring.type", Message: fmt.Sprintf("invalid value %q", recurring.Type)}
+	}
+	switch recurring.Sequence {
+	case RecurringFirst, RecurringSubsequent, RecurringLast:
+	default:
+		return &FieldError{Field: "recurring.sequence", Message: fmt.Sprintf("invalid value %q", recurring.Sequence)}
+	}
+	if recurring.Flag != "1" {
+		return &FieldError{Field: "recurring.flag", Message: fmt.Sprintf("invalid value %q", recurring.Flag)}
+	}
+	return nil
+}
+
+func validateRecurring(recurring *Recurring, storedCredential *StoredCredential) error {
+	if recurring == nil {
+		return nil
+	}
+	if storedCredential != nil {
+		return &FieldError{Field: "recurring", Message: "cannot be sent with storedcredential"}
+	}
+	return recurring.validate()
+}
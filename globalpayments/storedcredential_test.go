@@ -0,0 +1,172 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestStoredCredential_Helpers(t *testing.T) {
+	first := FirstStoredCredential(StoredCredentialRecurring)
+	if want := (&StoredCredential{Type: "recurring", Initiator: "cardholder", Sequence: "first"}); !reflect.DeepEqual(first, want) {
+		t.Errorf("FirstStoredCredential = %v, want %v", first, want)
+	}
+
+	response := &ServiceResponse{SRD: "MMC0F00YE4000000715"}
+	subsequent := response.SubsequentStoredCredential(StoredCredentialRecurring)
+	if want := (&StoredCredential{Type: "recurring", Initiator: "merchant", Sequence: "subsequent", SRD: "MMC0F00YE4000000715"}); !reflect.DeepEqual(subsequent, want) {
+		t.Errorf("SubsequentStoredCredential = %v, want %v", subsequent, want)
+	}
+}
+
+func TestCardStorageService_Authorize_StoredCredential(t *testing.T) {
+	initialResponse := &ServiceResponse{SRD: "MMC0F00YE4000000715"}
+
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		AutoSettle:       &AutoSettle{Flag: "1"},
+		StoredCredential: initialResponse.SubsequentStoredCredential(StoredCredentialRecurring),
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount><autosettle flag="1"></autosettle><storedcredential><type>recurring</type><initiator>merchant</initiator><sequence>subsequent</sequence><srd>MMC0F00YE4000000715</srd></storedcredential></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <timetaken>1</timetaken>
+							   <srd>MMC0F00YE4000000716</srd>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if got, want := response.SRD, "MMC0F00YE4000000716"; got != want {
+		t.Errorf("Response SRD = %v, want %v", got, want)
+	}
+}
+
+func TestRecurring_validateRecurring(t *testing.T) {
+	cases := []struct {
+		recurring        *Recurring
+		storedCredential *StoredCredential
+		err              string
+	}{
+		{nil, FirstStoredCredential(StoredCredentialRecurring), ""},
+		{NewRecurring(RecurringFixed, RecurringFirst), nil, ""},
+		{NewRecurring(RecurringVariable, RecurringLast), nil, ""},
+		{NewRecurring("monthly", RecurringFirst), nil, `Field Error: field: recurring.type, invalid value "monthly"`},
+		{NewRecurring(RecurringFixed, "second"), nil, `Field Error: field: recurring.sequence, invalid value "second"`},
+		{&Recurring{Type: RecurringFixed, Sequence: RecurringFirst}, nil, `Field Error: field: recurring.flag, invalid value ""`},
+		{NewRecurring(RecurringFixed, RecurringFirst), FirstStoredCredential(StoredCredentialRecurring), "Field Error: field: recurring, cannot be sent with storedcredential"},
+	}
+
+	for _, c := range cases {
+		err := validateRecurring(c.recurring, c.storedCredential)
+		if c.err == "" && err != nil {
+			t.Errorf("validateRecurring(%v, %v) returned %v, want no error", c.recurring, c.storedCredential, err)
+		}
+		if c.err != "" && (err == nil || err.Error() != c.err) {
+			t.Errorf("validateRecurring(%v, %v) returned %v, want %v", c.recurring, c.storedCredential, err, c.err)
+		}
+	}
+}
+
+func TestStoredCredential_validate(t *testing.T) {
+	cases := []struct {
+		storedCredential *StoredCredential
+		err              string
+	}{
+		{FirstStoredCredential(StoredCredentialInstallment), ""},
+		{MerchantInitiatedStoredCredential(StoredCredentialRecurring, "MMC0F00YE4000000715"), ""},
+		{&StoredCredential{Type: StoredCredentialOneOff, Initiator: InitiatorCardholder, Sequence: SequenceSubsequent}, ""},
+		{FirstStoredCredential("monthly"), `Field Error: field: storedcredential.type, invalid value "monthly"`},
+		{&StoredCredential{Type: StoredCredentialOneOff, Initiator: "customer", Sequence: SequenceFirst}, `Field Error: field: storedcredential.initiator, invalid value "customer"`},
+		{&StoredCredential{Type: StoredCredentialOneOff, Initiator: InitiatorCardholder, Sequence: "last"}, `Field Error: field: storedcredential.sequence, invalid value "last"`},
+		{(&ServiceResponse{}).SubsequentStoredCredential(StoredCredentialRecurring), "Field Error: field: storedcredential.srd, required for a subsequent merchant initiated transaction"},
+	}
+
+	for _, c := range cases {
+		err := validateStoredCredential(c.storedCredential)
+		if c.err == "" && err != nil {
+			t.Errorf("validateStoredCredential(%v) returned %v, want no error", c.storedCredential, err)
+		}
+		if c.err != "" && (err == nil || err.Error() != c.err) {
+			t.Errorf("validateStoredCredential(%v) returned %v, want %v", c.storedCredential, err, c.err)
+		}
+	}
+}
+
+func TestCardStorageService_Authorize_Recurring(t *testing.T) {
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		Recurring: NewRecurring(RecurringFixed, RecurringSubsequent),
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount><recurring type="fixed" sequence="subsequent" flag="1"></recurring></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <timetaken>1</timetaken>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	_, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	authRequest.StoredCredential = MerchantInitiatedStoredCredential(StoredCredentialRecurring, "MMC0F00YE4000000715")
+	response, httpResponse, err := client.CardStorage.Authorize(authRequest)
+	if err == nil || response != nil || httpResponse != nil {
+		t.Errorf("Authorize with recurring and storedcredential returned %v, %v, %v, want a field error", response, httpResponse, err)
+	}
+}
@@ -0,0 +1,104 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"fmt"
+	"unicode/utf8"
+)
+
+//Limits on comments and supplementary data enforced by Global Payments
+const (
+	MaxComments                 = 2
+	MaxCommentLength            = 255
+	MaxSupplementaryItems       = 10
+	MaxSupplementaryFields      = 10
+	MaxSupplementaryTypeLength  = 50
+	MaxSupplementaryFieldLength = 255
+)
+
+//Comment request struct. Comments are shown against the transaction in the Ecommerce Portal.
+type Comment struct {
+	ID   int    `xml:"id,attr"`
+	Text string `xml:",chardata"`
+}
+
+//Comments request struct
+type Comments []Comment
+
+//MarshalXML wraps each comment in a comment element
+func (comments Comments) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(encoder, start, "comment", []Comment(comments))
+}
+
+//NewComments returns the comments for a request numbered in order
+func NewComments(comments ...string) Comments {
+	numbered := make(Comments, len(comments))
+	for i, comment := range comments {
+		numbered[i] = Comment{ID: i + 1, Text: comment}
+	}
+	return numbered
+}
+
+//SupplementaryItem request struct for custom reference data that is passed through to settlement reports. Fields are sent
+//as field01, field02 and so on.
+type SupplementaryItem struct {
+	Type   string
+	Fields []string
+}
+
+//SupplementaryData request struct
+type SupplementaryData []SupplementaryItem
+
+//MarshalXML wraps each item in an item element
+func (data SupplementaryData) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	return encodeWrapped(encoder, start, "item", []SupplementaryItem(data))
+}
+
+//MarshalXML numbers the fields of the item
+func (item SupplementaryItem) MarshalXML(encoder *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "type"}, Value: item.Type})
+	err := encoder.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	for i, field := range item.Fields {
+		err = encoder.EncodeElement(field, xml.StartElement{Name: xml.Name{Local: fmt.Sprintf("field%02d", i+1)}})
+		if err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+//comment checks a free text comment against the comment length of the gateway
+func (validator *fieldValidator) comment(field string, text string) {
+	if utf8.RuneCountInString(text) > MaxCommentLength {
+		validator.add(field, fmt.Sprintf("must be at most %d characters", MaxCommentLength))
+	}
+}
+
+//supplementaryData checks comments and supplementary data against the limits of the gateway
+func (validator *fieldValidator) supplementaryData(comments Comments, items SupplementaryData) {
+	if len(comments) > MaxComments {
+		validator.add("comments", fmt.Sprintf("at most %d comments are allowed", MaxComments))
+	}
+	for _, comment := range comments {
+		validator.comment(fmt.Sprintf("comments.comment[%d]", comment.ID), comment.Text)
+	}
+	if len(items) > MaxSupplementaryItems {
+		validator.add("supplementarydata", fmt.Sprintf("at most %d items are allowed", MaxSupplementaryItems))
+	}
+	for i, item := range items {
+		if item.Type == "" || utf8.RuneCountInString(item.Type) > MaxSupplementaryTypeLength {
+			validator.add(fmt.Sprintf("supplementarydata.item[%d].type", i), fmt.Sprintf("must be between 1 and %d characters", MaxSupplementaryTypeLength))
+		}
+		if len(item.Fields) > MaxSupplementaryFields {
+			validator.add(fmt.Sprintf("supplementarydata.item[%d]", i), fmt.Sprintf("at most %d fields are allowed", MaxSupplementaryFields))
+		}
+		for j, field := range item.Fields {
+			if utf8.RuneCountInString(field) > MaxSupplementaryFieldLength {
+				validator.add(fmt.Sprintf("supplementarydata.item[%d].field%02d", i, j+1), fmt.Sprintf("must be at most %d characters", MaxSupplementaryFieldLength))
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCardStorageService_Authorize_SupplementaryData(t *testing.T) {
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		Comments:          NewComments("Mobile Channel", "Down Payment"),
+		SupplementaryData: SupplementaryData{{Type: "taxinfo", Fields: []string{"VAT", "20"}}},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount><comments><comment id="1">Mobile Channel</comment><comment id="2">Down Payment</comment></comments><supplementarydata><item type="taxinfo"><field01>VAT</field01><field02>20</field02></item></supplementarydata></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	_, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+}
+
+func TestValidateSupplementaryData(t *testing.T) {
+	fixNow(t)
+	tests := []struct {
+		comments Comments
+		items    SupplementaryData
+		field    string
+	}{
+		{NewComments("one", "two", "three"), nil, "comments"},
+		{NewComments(strings.Repeat("a", 256)), nil, "comments.comment[1]"},
+		{nil, SupplementaryData{{Fields: []string{"VAT"}}}, "supplementarydata.item[0].type"},
+		{nil, SupplementaryData{{Type: "taxinfo", Fields: make([]string, 11)}}, "supplementarydata.item[0]"},
+		{nil, SupplementaryData{{Type: "taxinfo", Fields: []string{"VAT", strings.Repeat("a", 256)}}}, "supplementarydata.item[0].field02"},
+	}
+
+	for _, test := range tests {
+		validator := &fieldValidator{}
+		validator.supplementaryData(test.comments, test.items)
+		if got := fmt.Sprint(fieldErrorFields(validator.err())); got != "["+test.field+"]" {
+			t.Errorf("supplementaryData error fields = %v, want [%v]", got, test.field)
+		}
+	}
+
+	client, _ := NewClient()
+	storeCardRequest := &CardStorageRequest{
+		Card:     &Card{Ref: "3c4af936-483e-a393-f558bec2fb2a", PayerRef: "0f357b45-9aa4-4453-a685-c69232e9024f", Number: "4263970000005262", ExpDate: "0525", CardHolderName: "James Mason"},
+		Comments: NewComments("one", "two", "three"),
+	}
+	_, _, err := client.CardStorage.StoreCard(storeCardRequest)
+	if got, want := fmt.Sprint(fieldErrorFields(err)), "[comments]"; got != want {
+		t.Errorf("StoreCard error fields = %v, want %v", got, want)
+	}
+
+	hppRequest := newHPPRequest()
+	hppRequest.Comment1 = strings.Repeat("a", 256)
+	err = client.HPP.Sign(hppRequest)
+	if got, want := fmt.Sprint(fieldErrorFields(err)), "[COMMENT1]"; got != want {
+		t.Errorf("HPP Sign error fields = %v, want %v", got, want)
+	}
+
+	scheduleRequest := &ScheduleRequest{ScheduleRef: "ScheduleRef", Comment: strings.Repeat("a", 256)}
+	_, _, err = client.Schedules.Get(scheduleRequest)
+	if got, want := fmt.Sprint(fieldErrorFields(err)), "[comment]"; got != want {
+		t.Errorf("Schedules Get error fields = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+package globalpayments
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+)
+
+//3D Secure enrolment and authentication status values returned by Global Payments
+const (
+	ThreeDSecureEnrolled       = "Y"
+	ThreeDSecureNotEnrolled    = "N"
+	ThreeDSecureUnableToVerify = "U"
+
+	ThreeDSecureAuthenticated = "Y"
+	ThreeDSecureAttempted     = "A"
+	ThreeDSecureFailed        = "N"
+	ThreeDSecureUnavailable   = "U"
+)
+
+//ThreeDSecure response struct for the result of a 3D Secure signature verification
+type ThreeDSecure struct {
+	Status    string `xml:"status"`
+	ECI       string `xml:"eci"`
+	XID       string `xml:"xid"`
+	CAVV      string `xml:"cavv"`
+	Algorithm string `xml:"algorithm"`
+}
+
+//ThreeDSecureResponse response struct for 3D Secure apis. URL and PaReq are populated by VerifyEnrolled and ThreeDSecure
+//by VerifySig.
+type ThreeDSecureResponse struct {
+	XMLName xml.Name `xml:"response"`
+	ServiceResponse
+	URL          string        `xml:"url"`
+	PaReq        string        `xml:"pareq"`
+	Enrolled     string        `xml:"enrolled"`
+	XID          string        `xml:"xid"`
+	ThreeDSecure *ThreeDSecure `xml:"threedsecure"`
+}
+
+//IsEnrolled reports whether the card is enrolled in 3D Secure and the cardholder must be redirected to the ACS URL
+func (response *ThreeDSecureResponse) IsEnrolled() bool {
+	return response.Enrolled == ThreeDSecureEnrolled
+}
+
+//ACSForm returns the form values to POST to the issuer's ACS URL. termURL is where the ACS posts the PaRes back to and md is
+//returned with it unchanged, so it can be used to find the order the PaRes belongs to.
+func (response *ThreeDSecureResponse) ACSForm(termURL string, md string) url.Values {
+	return url.Values{
+		"PaReq":   {response.PaReq},
+		"TermUrl": {termURL},
+		"MD":      {md},
+	}
+}
+
+//ParseACSResponse returns the PaRes and MD posted back to the TermUrl by the issuer's ACS. The PaRes is sent on VerifySig.
+func ParseACSResponse(r *http.Request) (paRes string, md string, err error) {
+	err = r.ParseForm()
+	if err != nil {
+		return "", "", err
+	}
+	return r.PostForm.Get("PaRes"), r.PostForm.Get("MD"), nil
+}
+
+//MPI returns the authentication values to send on Authorize, or nil if the cardholder failed authentication and the
+//transaction should not proceed.
+func (response *ThreeDSecureResponse) MPI() *MPI {
+	if response.ThreeDSecure == nil || response.ThreeDSecure.Status == ThreeDSecureFailed {
+		return nil
+	}
+	return &MPI{CAVV: response.ThreeDSecure.CAVV, XID: response.ThreeDSecure.XID, ECI: response.ThreeDSecure.ECI}
+}
+
+func (transmitter *service) transmitThreeDSecureRequest(request interface{}) (*ThreeDSecureResponse, *http.Response,
+	error) {
+	response := &ThreeDSecureResponse{}
+	httpResponse, err := transmitter.transmit(request, response)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	return response, httpResponse, nil
+}
+
+//VerifyEnrolled checks whether a stored card is enrolled in 3D Secure. If it is, the cardholder is redirected to the
+//returned ACS URL with the PaReq, and the PaRes posted back is checked with PaymentsService.VerifySig.
+func (cardStorage *CardStorageService) VerifyEnrolled(request *CardStorageRequest) (*ThreeDSecureResponse, *http.Response,
+	error) {
+	request.applyChannel(cardStorage.client)
+	if err := request.validate(requestTypeRealvaultVerifyEnrolled); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = cardStorage.client.MerchantID
+	request.Type = requestTypeRealvaultVerifyEnrolled
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.PayerRef}
+	request.sharedSecret = cardStorage.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return cardStorage.transmitThreeDSecureRequest(request)
+}
+
+//VerifyEnrolled checks whether the card supplied with the request is enrolled in 3D Secure. If it is, the cardholder is
+//redirected to the returned ACS URL with the PaReq, and the PaRes posted back is checked with VerifySig.
+func (payments *PaymentsService) VerifyEnrolled(request *PaymentRequest) (*ThreeDSecureResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeVerifyEnrolled); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeVerifyEnrolled
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitThreeDSecureRequest(request)
+}
+
+//VerifySig checks the signature of the PaRes returned by the issuer's ACS and returns the ECI, CAVV and XID of the
+//authentication. These are sent on the authorization through ThreeDSecureResponse.MPI.
+func (payments *PaymentsService) VerifySig(request *PaymentRequest) (*ThreeDSecureResponse, *http.Response,
+	error) {
+	request.applyChannel(payments.client)
+	if err := request.validate(requestTypeVerifySig); err != nil {
+		return nil, nil, err
+	}
+	request.Timestamp = formatTime(Now(), "20060102150405")
+	request.MerchantID = payments.client.MerchantID
+	request.Type = requestTypeVerifySig
+	request.elementsToHash = []string{request.Timestamp, request.MerchantID, request.OrderID, request.getAmount(), request.getCurrency(), request.getCardNumber()}
+	request.sharedSecret = payments.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Sha1Hash = signature
+	return payments.transmitThreeDSecureRequest(request)
+}
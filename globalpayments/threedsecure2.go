@@ -0,0 +1,409 @@
+package globalpayments
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+//ThreeDSecureVersion version of the 3D Secure 2 API sent with every request
+const ThreeDSecureVersion = "2.2.0"
+
+//3D Secure 2 values accepted and returned by Global Payments
+const (
+	AuthenticationSourceBrowser = "BROWSER"
+
+	AuthenticationRequestPayment = "PAYMENT_TRANSACTION"
+
+	MessageCategoryPayment = "PAYMENT_AUTHENTICATION"
+
+	MethodURLCompletionYes         = "YES"
+	MethodURLCompletionNo          = "NO"
+	MethodURLCompletionUnavailable = "UNAVAILABLE"
+
+	ChallengeNoPreference = "NO_PREFERENCE"
+	ChallengeNotRequested = "NO_CHALLENGE_REQUESTED"
+	ChallengePreferred    = "CHALLENGE_PREFERRED"
+	ChallengeMandated     = "CHALLENGE_MANDATED"
+
+	ChallengeWindowFullScreen = "FULL_SCREEN"
+
+	AuthenticationSuccessful   = "AUTHENTICATION_SUCCESSFUL"
+	AuthenticationAttempted    = "AUTHENTICATION_ATTEMPTED"
+	AuthenticationFailed       = "AUTHENTICATION_FAILED"
+	AuthenticationNotPerformed = "AUTHENTICATION_COULD_NOT_BE_PERFORMED"
+	AuthenticationChallenge    = "CHALLENGE_REQUIRED"
+	AuthenticationNotEnrolled  = "NOT_ENROLLED"
+)
+
+//ThreeDSecureError for 3D Secure 2 responses with an unsuccessful status code
+type ThreeDSecureError struct {
+	Response *http.Response
+	Body     string
+}
+
+func (err *ThreeDSecureError) Error() string {
+	return fmt.Sprintf("3D Secure Error: method: %v, path: %v, status code:%d, body: %v", err.Response.Request.Method,
+		err.Response.Request.URL.Path, err.Response.StatusCode, err.Body)
+}
+
+//CheckVersionRequest request struct for checking the 3D Secure 2 versions supported by a card. Either Number for card data
+//or PayerReference and PaymentMethodReference for a stored card are sent.
+type CheckVersionRequest struct {
+	RequestTimestamp       string `json:"request_timestamp"`
+	MerchantID             string `json:"merchant_id"`
+	AccountID              string `json:"account_id"`
+	Number                 string `json:"number,omitempty"`
+	Scheme                 string `json:"scheme,omitempty"`
+	PayerReference         string `json:"payer_reference,omitempty"`
+	PaymentMethodReference string `json:"payment_method_reference,omitempty"`
+	MethodNotificationURL  string `json:"method_notification_url"`
+	//Channel the transaction is taken through, checked before the request is sent. The client's channel is used if empty.
+	Channel Channel `json:"-"`
+	serviceAuthenticator
+}
+
+//MethodData response struct
+type MethodData struct {
+	EncodedMethodData string `json:"encoded_method_data"`
+}
+
+//CheckVersionResponse response struct. If MethodURL is set it is loaded in a hidden iframe with MethodForm before the
+//authentication is initiated.
+type CheckVersionResponse struct {
+	Enrolled                string      `json:"enrolled"`
+	ServerTransID           string      `json:"server_trans_id"`
+	DSProtocolVersionStart  string      `json:"ds_protocol_version_start"`
+	DSProtocolVersionEnd    string      `json:"ds_protocol_version_end"`
+	ACSProtocolVersionStart string      `json:"acs_protocol_version_start"`
+	ACSProtocolVersionEnd   string      `json:"acs_protocol_version_end"`
+	MethodURL               string      `json:"method_url"`
+	MethodData              *MethodData `json:"method_data"`
+	MessageType             string      `json:"message_type"`
+}
+
+//CardDetail request struct for the card being authenticated, either card data or a stored card reference
+type CardDetail struct {
+	Number                 string `json:"number,omitempty"`
+	Scheme                 string `json:"scheme,omitempty"`
+	ExpiryMonth            string `json:"expiry_month,omitempty"`
+	ExpiryYear             string `json:"expiry_year,omitempty"`
+	FullName               string `json:"full_name,omitempty"`
+	PayerReference         string `json:"payer_reference,omitempty"`
+	PaymentMethodReference string `json:"payment_method_reference,omitempty"`
+}
+
+//Order request struct for the transaction being authenticated
+type Order struct {
+	DateTimeCreated string `json:"date_time_created"`
+	Amount          string `json:"amount"`
+	Currency        string `json:"currency"`
+	ID              string `json:"id"`
+	TransactionType string `json:"transaction_type,omitempty"`
+}
+
+//BrowserData request struct for the cardholder's browser, collected on the checkout page
+type BrowserData struct {
+	AcceptHeader        string `json:"accept_header"`
+	ColorDepth          string `json:"color_depth"`
+	IP                  string `json:"ip"`
+	JavaEnabled         bool   `json:"java_enabled"`
+	JavascriptEnabled   bool   `json:"javascript_enabled"`
+	Language            string `json:"language"`
+	ScreenHeight        int    `json:"screen_height"`
+	ScreenWidth         int    `json:"screen_width"`
+	ChallengeWindowSize string `json:"challenge_window_size"`
+	Timezone            string `json:"timezone"`
+	UserAgent           string `json:"user_agent"`
+}
+
+//AuthenticationRequest request struct for initiating a 3D Secure 2 authentication
+type AuthenticationRequest struct {
+	RequestTimestamp          string       `json:"request_timestamp"`
+	AuthenticationSource      string       `json:"authentication_source"`
+	AuthenticationRequestType string       `json:"authentication_request_type"`
+	MessageCategory           string       `json:"message_category"`
+	MessageVersion            string       `json:"message_version"`
+	ServerTransID             string       `json:"server_trans_id"`
+	MerchantID                string       `json:"merchant_id"`
+	AccountID                 string       `json:"account_id"`
+	ChallengeNotificationURL  string       `json:"challenge_notification_url"`
+	MethodURLCompletion       string       `json:"method_url_completion"`
+	MerchantContactURL        string       `json:"merchant_contact_url,omitempty"`
+	ChallengeRequestIndicator string       `json:"challenge_request_indicator,omitempty"`
+	CardDetail                *CardDetail  `json:"card_detail"`
+	Order                     *Order       `json:"order"`
+	BrowserData               *BrowserData `json:"browser_data,omitempty"`
+	//Channel the transaction is taken through, checked before the request is sent. The client's channel is used if empty.
+	Channel Channel `json:"-"`
+	serviceAuthenticator
+}
+
+//AuthenticationResult response struct for an initiated authentication or its result
+type AuthenticationResult struct {
+	ACSTransID          string `json:"acs_trans_id"`
+	AuthenticationValue string `json:"authentication_value"`
+	ChallengeMandated   bool   `json:"challenge_mandated"`
+	ChallengeRequestURL string `json:"challenge_request_url"`
+	DSTransID           string `json:"ds_trans_id"`
+	ECI                 string `json:"eci"`
+	EncodedCReq         string `json:"encoded_creq"`
+	MessageCategory     string `json:"message_category"`
+	MessageVersion      string `json:"message_version"`
+	ServerTransID       string `json:"server_trans_id"`
+	Status              string `json:"status"`
+	StatusReason        string `json:"status_reason"`
+}
+
+//MethodNotification is posted by the ACS to the method notification URL once the method URL has loaded
+type MethodNotification struct {
+	ServerTransID string `json:"threeDSServerTransID"`
+}
+
+//ChallengeNotification is posted by the ACS to the challenge notification URL once the cardholder completes the challenge
+type ChallengeNotification struct {
+	ServerTransID          string `json:"threeDSServerTransID"`
+	ACSTransID             string `json:"acsTransID"`
+	ChallengeCompletionInd string `json:"challengeCompletionInd"`
+	MessageType            string `json:"messageType"`
+	MessageVersion         string `json:"messageVersion"`
+	TransStatus            string `json:"transStatus"`
+}
+
+//MethodForm returns the form values to POST to the method URL from a hidden iframe
+func (response *CheckVersionResponse) MethodForm() url.Values {
+	if response.MethodData == nil {
+		return url.Values{}
+	}
+	return url.Values{"threeDSMethodData": {response.MethodData.EncodedMethodData}}
+}
+
+//ChallengeRequired reports whether the cardholder must complete a challenge at the ChallengeRequestURL
+func (result *AuthenticationResult) ChallengeRequired() bool {
+	return result.Status == AuthenticationChallenge
+}
+
+//ChallengeForm returns the form values to POST to the challenge request URL
+func (result *AuthenticationResult) ChallengeForm() url.Values {
+	return url.Values{"creq": {result.EncodedCReq}}
+}
+
+//MPI returns the authentication values to send on an authorization, or nil if the authentication did not succeed or was not
+//attempted and the transaction should not proceed with liability shift.
+func (result *AuthenticationResult) MPI() *MPI {
+	if result.Status != AuthenticationSuccessful && result.Status != AuthenticationAttempted {
+		return nil
+	}
+	return &MPI{ECI: result.ECI, DSTransID: result.DSTransID, AuthenticationValue: result.AuthenticationValue,
+		MessageVersion: result.MessageVersion}
+}
+
+func decodeNotification(encoded string, v interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, v)
+}
+
+//ParseMethodNotification decodes the threeDSMethodData posted to the method notification URL
+func ParseMethodNotification(r *http.Request) (*MethodNotification, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	notification := &MethodNotification{}
+	err = decodeNotification(r.PostForm.Get("threeDSMethodData"), notification)
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+//ParseChallengeNotification decodes the cres posted to the challenge notification URL. The authentication result is then
+//retrieved with ThreeDSecureService.GetResult.
+func ParseChallengeNotification(r *http.Request) (*ChallengeNotification, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	notification := &ChallengeNotification{}
+	err = decodeNotification(r.PostForm.Get("cres"), notification)
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+//ThreeDSecureService 3D Secure 2 API authenticates cardholders for Strong Customer Authentication. Unlike the other services
+//it is a JSON API signed with a securehash header, and its results are sent on authorizations through an MPI.
+type ThreeDSecureService struct {
+	service
+}
+
+//ThreeDSecureServiceAPI interface contain all request types that are allowed within this service for mocking on upstream consumers
+type ThreeDSecureServiceAPI interface {
+	CheckVersion(request *CheckVersionRequest) (*CheckVersionResponse, *http.Response,
+		error)
+	InitiateAuthentication(request *AuthenticationRequest) (*AuthenticationResult, *http.Response,
+		error)
+	GetResult(serverTransID string) (*AuthenticationResult, *http.Response,
+		error)
+}
+
+func (threeDSecure *ThreeDSecureService) newRequest(method, path string, body interface{}, signature string) (*http.Request, error) {
+
+	rel, err := threeDSecure.client.ThreeDSecureBaseURL.Parse(threeDSecure.Path + path)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer io.ReadWriter
+	if body != nil {
+		buffer = &bytes.Buffer{}
+		err := json.NewEncoder(buffer).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, rel.String(), buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "securehash "+signature)
+	req.Header.Set("X-GP-Version", ThreeDSecureVersion)
+	return req, nil
+}
+
+func (threeDSecure *ThreeDSecureService) do(req *http.Request, v interface{}) (*http.Response, error) {
+
+	resp, err := threeDSecure.client.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp, &ThreeDSecureError{Response: resp, Body: string(body)}
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+//CheckVersion checks whether the card is enrolled in 3D Secure 2, and returns the server transaction ID used by the rest of
+//the authentication along with the method URL, if the ACS has one.
+func (threeDSecure *ThreeDSecureService) CheckVersion(request *CheckVersionRequest) (*CheckVersionResponse, *http.Response,
+	error) {
+	request.Channel = threeDSecure.client.requestChannel(request.Channel)
+	if err := request.validate(); err != nil {
+		return nil, nil, err
+	}
+	request.RequestTimestamp = formatTime(Now(), "2006-01-02T15:04:05.000000")
+	request.MerchantID = threeDSecure.client.MerchantID
+	cardReference := request.Number
+	if cardReference == "" {
+		cardReference = request.PayerReference
+	}
+	request.elementsToHash = []string{request.RequestTimestamp, request.MerchantID, cardReference, request.MethodNotificationURL}
+	request.sharedSecret = threeDSecure.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpRequest, err := threeDSecure.newRequest("POST", "/protocol-versions", request, signature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := &CheckVersionResponse{}
+	httpResponse, err := threeDSecure.do(httpRequest, response)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	return response, httpResponse, nil
+}
+
+//InitiateAuthentication starts the authentication for the server transaction ID returned by CheckVersion. The result is either
+//final, or requires the cardholder to complete a challenge after which it is retrieved with GetResult.
+func (threeDSecure *ThreeDSecureService) InitiateAuthentication(request *AuthenticationRequest) (*AuthenticationResult, *http.Response,
+	error) {
+	request.Channel = threeDSecure.client.requestChannel(request.Channel)
+	if err := request.validate(); err != nil {
+		return nil, nil, err
+	}
+	request.RequestTimestamp = formatTime(Now(), "2006-01-02T15:04:05.000000")
+	request.MerchantID = threeDSecure.client.MerchantID
+	cardReference := ""
+	if request.CardDetail != nil {
+		cardReference = request.CardDetail.Number
+		if cardReference == "" {
+			cardReference = request.CardDetail.PayerReference
+		}
+	}
+	request.elementsToHash = []string{request.RequestTimestamp, request.MerchantID, cardReference, request.ServerTransID}
+	request.sharedSecret = threeDSecure.client.HashSecret
+	signature, err := request.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpRequest, err := threeDSecure.newRequest("POST", "/authentications", request, signature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &AuthenticationResult{}
+	httpResponse, err := threeDSecure.do(httpRequest, result)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	return result, httpResponse, nil
+}
+
+//GetResult retrieves the result of an authentication once the cardholder has completed a challenge. channel is the channel
+//the authentication was started on, or empty for the client's channel.
+func (threeDSecure *ThreeDSecureService) GetResult(serverTransID string, channel Channel) (*AuthenticationResult, *http.Response,
+	error) {
+	validator := &fieldValidator{}
+	validator.channel(threeDSecure.client.requestChannel(channel), nil, nil, true)
+	if err := validator.err(); err != nil {
+		return nil, nil, err
+	}
+	timestamp := formatTime(Now(), "2006-01-02T15:04:05.000000")
+	authenticator := &serviceAuthenticator{
+		elementsToHash: []string{timestamp, threeDSecure.client.MerchantID, serverTransID},
+		sharedSecret:   threeDSecure.client.HashSecret,
+	}
+	signature, err := authenticator.buildSignature()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := url.Values{"merchant_id": {threeDSecure.client.MerchantID}, "request_timestamp": {timestamp}}
+	httpRequest, err := threeDSecure.newRequest("GET", "/authentications/"+url.PathEscape(serverTransID)+"?"+query.Encode(), nil, signature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &AuthenticationResult{}
+	httpResponse, err := threeDSecure.do(httpRequest, result)
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	return result, httpResponse, nil
+}
@@ -0,0 +1,243 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const serverTransID = "af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5"
+
+//Setup new client with the 3D Secure 2 API pointed at the test server
+func setupThreeDSecure() (client *Client, mux *http.ServeMux, teardown func()) {
+	client, mux, serverURL, teardown := setup()
+	threeDSecureURL, _ := url.Parse(serverURL)
+	client.ThreeDSecureBaseURL = threeDSecureURL
+	return client, mux, teardown
+}
+
+func TestThreeDSecureService_CheckVersion(t *testing.T) {
+	checkVersionRequest := &CheckVersionRequest{
+		AccountID:             "internet",
+		Number:                "4263970000005262",
+		Scheme:                "VISA",
+		MethodNotificationURL: "https://merchant.example.com/3ds2/method",
+	}
+
+	client, mux, teardown := setupThreeDSecure()
+	defer teardown()
+	mux.HandleFunc("/3ds2/protocol-versions", func(w http.ResponseWriter, r *http.Request) {
+		requestJSONBody := `{"request_timestamp":"2018-06-14T09:50:00.000000","merchant_id":"realexsandbox","account_id":"internet","number":"4263970000005262","scheme":"VISA","method_notification_url":"https://merchant.example.com/3ds2/method"}` + "\n"
+		responseJSONBody := `{
+			"enrolled": "True",
+			"server_trans_id": "af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5",
+			"ds_protocol_version_start": "2.1.0",
+			"ds_protocol_version_end": "2.1.0",
+			"acs_protocol_version_start": "2.1.0",
+			"acs_protocol_version_end": "2.1.0",
+			"method_url": "https://acs.example.com/method",
+			"method_data": {"encoded_method_data": "eyJ0aHJlZURTU2VydmVyVHJhbnNJRCI6ImFmNjVjMzY5In0"},
+			"message_type": "ProtocolVersionResponse"
+		}`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		if got, want := r.Header.Get("Authorization"), "securehash b35b0c6151b07561486b78d04247ec37906ff559"; got != want {
+			t.Errorf("Request Authorization header: %v, want %v", got, want)
+		}
+		if got, want := r.Header.Get("X-GP-Version"), "2.2.0"; got != want {
+			t.Errorf("Request X-GP-Version header: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if got := string(body); got != requestJSONBody {
+			t.Errorf("Request Body = %v, want %v", got, requestJSONBody)
+		}
+		fmt.Fprint(w, responseJSONBody)
+	})
+
+	response, _, err := client.ThreeDSecure.CheckVersion(checkVersionRequest)
+	if err != nil {
+		t.Errorf("Error performing CheckVersion: %v", err)
+	}
+
+	if got, want := response.ServerTransID, serverTransID; got != want {
+		t.Errorf("Response ServerTransID = %v, want %v", got, want)
+	}
+
+	expectedForm := url.Values{"threeDSMethodData": {"eyJ0aHJlZURTU2VydmVyVHJhbnNJRCI6ImFmNjVjMzY5In0"}}
+	if !reflect.DeepEqual(response.MethodForm(), expectedForm) {
+		t.Errorf("MethodForm = %v, want %v", response.MethodForm(), expectedForm)
+	}
+}
+
+func TestThreeDSecureService_CheckVersion_Error(t *testing.T) {
+	client, mux, teardown := setupThreeDSecure()
+	defer teardown()
+	mux.HandleFunc("/3ds2/protocol-versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"INVALID_REQUEST_DATA"}`)
+	})
+
+	response, _, err := client.ThreeDSecure.CheckVersion(&CheckVersionRequest{Number: "4263970000005262"})
+
+	if got, want := err.Error(), `3D Secure Error: method: POST, path: /3ds2/protocol-versions, status code:400, body: {"error":"INVALID_REQUEST_DATA"}`; got != want {
+		t.Errorf("Incorrect 3D Secure Error thrown got: %v, want: %v", got, want)
+	}
+
+	if response != nil {
+		t.Errorf("Response supposed to be nil, got: %v", response)
+	}
+}
+
+func TestThreeDSecureService_InitiateAuthentication(t *testing.T) {
+	authenticationRequest := &AuthenticationRequest{
+		AuthenticationSource:      AuthenticationSourceBrowser,
+		AuthenticationRequestType: AuthenticationRequestPayment,
+		MessageCategory:           MessageCategoryPayment,
+		MessageVersion:            "2.1.0",
+		ServerTransID:             serverTransID,
+		AccountID:                 "internet",
+		ChallengeNotificationURL:  "https://merchant.example.com/3ds2/challenge",
+		MethodURLCompletion:       MethodURLCompletionYes,
+		CardDetail: &CardDetail{
+			PayerReference:         "03e28f0e-492e-80bd-20ec318e9334",
+			PaymentMethodReference: "3c4af936-483e-a393-f558bec2fb2a",
+		},
+		Order: &Order{DateTimeCreated: "2018-06-14T09:50:00.000000", Amount: "10000", Currency: "CAD", ID: "AiCibJ5UR7utURy_slxhJw"},
+		BrowserData: &BrowserData{
+			AcceptHeader:        "text/html",
+			ColorDepth:          "TWENTY_FOUR_BITS",
+			IP:                  "123.123.123.123",
+			JavascriptEnabled:   true,
+			Language:            "en",
+			ScreenHeight:        1080,
+			ScreenWidth:         1920,
+			ChallengeWindowSize: ChallengeWindowFullScreen,
+			Timezone:            "0",
+			UserAgent:           "Mozilla/5.0",
+		},
+	}
+
+	client, mux, teardown := setupThreeDSecure()
+	defer teardown()
+	mux.HandleFunc("/3ds2/authentications", func(w http.ResponseWriter, r *http.Request) {
+		requestJSONBody := `{"request_timestamp":"2018-06-14T09:50:00.000000","authentication_source":"BROWSER","authentication_request_type":"PAYMENT_TRANSACTION","message_category":"PAYMENT_AUTHENTICATION","message_version":"2.1.0","server_trans_id":"af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5","merchant_id":"realexsandbox","account_id":"internet","challenge_notification_url":"https://merchant.example.com/3ds2/challenge","method_url_completion":"YES","card_detail":{"payer_reference":"03e28f0e-492e-80bd-20ec318e9334","payment_method_reference":"3c4af936-483e-a393-f558bec2fb2a"},"order":{"date_time_created":"2018-06-14T09:50:00.000000","amount":"10000","currency":"CAD","id":"AiCibJ5UR7utURy_slxhJw"},"browser_data":{"accept_header":"text/html","color_depth":"TWENTY_FOUR_BITS","ip":"123.123.123.123","java_enabled":false,"javascript_enabled":true,"language":"en","screen_height":1080,"screen_width":1920,"challenge_window_size":"FULL_SCREEN","timezone":"0","user_agent":"Mozilla/5.0"}}` + "\n"
+		responseJSONBody := `{
+			"acs_trans_id": "13c701a3-5a88-4c45-89e9-ef65e50a8bf9",
+			"challenge_mandated": true,
+			"challenge_request_url": "https://acs.example.com/challenge",
+			"ds_trans_id": "c272b04f-6e7b-43a2-bb78-90f4fb94aa25",
+			"encoded_creq": "eyJ0aHJlZURTU2VydmVyVHJhbnNJRCI6ImFmNjVjMzY5In0",
+			"message_category": "PAYMENT_AUTHENTICATION",
+			"message_version": "2.1.0",
+			"server_trans_id": "af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5",
+			"status": "CHALLENGE_REQUIRED"
+		}`
+		if got, want := r.Header.Get("Authorization"), "securehash fff0c34f0e37af1d0dbb119a8b13b18d2eb79308"; got != want {
+			t.Errorf("Request Authorization header: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if got := string(body); got != requestJSONBody {
+			t.Errorf("Request Body = %v, want %v", got, requestJSONBody)
+		}
+		fmt.Fprint(w, responseJSONBody)
+	})
+
+	result, _, err := client.ThreeDSecure.InitiateAuthentication(authenticationRequest)
+	if err != nil {
+		t.Errorf("Error performing InitiateAuthentication: %v", err)
+	}
+
+	if !result.ChallengeRequired() {
+		t.Errorf("Result ChallengeRequired = false for status %v", result.Status)
+	}
+
+	if mpi := result.MPI(); mpi != nil {
+		t.Errorf("Result MPI = %v before the challenge is completed, want nil", mpi)
+	}
+
+	expectedForm := url.Values{"creq": {"eyJ0aHJlZURTU2VydmVyVHJhbnNJRCI6ImFmNjVjMzY5In0"}}
+	if !reflect.DeepEqual(result.ChallengeForm(), expectedForm) {
+		t.Errorf("ChallengeForm = %v, want %v", result.ChallengeForm(), expectedForm)
+	}
+}
+
+func TestThreeDSecureService_GetResult(t *testing.T) {
+	client, mux, teardown := setupThreeDSecure()
+	defer teardown()
+	mux.HandleFunc("/3ds2/authentications/"+serverTransID, func(w http.ResponseWriter, r *http.Request) {
+		responseJSONBody := `{
+			"acs_trans_id": "13c701a3-5a88-4c45-89e9-ef65e50a8bf9",
+			"authentication_value": "ODQzNjgwNjU0ZjM3N2JmYTg0NTM=",
+			"ds_trans_id": "c272b04f-6e7b-43a2-bb78-90f4fb94aa25",
+			"eci": "05",
+			"message_category": "PAYMENT_AUTHENTICATION",
+			"message_version": "2.1.0",
+			"server_trans_id": "af65c369-59b9-4f8d-b2f6-7d7d5f5c69d5",
+			"status": "AUTHENTICATION_SUCCESSFUL"
+		}`
+		if got, want := r.Method, "GET"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		if got, want := r.Header.Get("Authorization"), "securehash 4ad330d23997329f76226cd543a46b9eac4f3e63"; got != want {
+			t.Errorf("Request Authorization header: %v, want %v", got, want)
+		}
+		if got, want := r.URL.Query().Get("request_timestamp"), "2018-06-14T09:50:00.000000"; got != want {
+			t.Errorf("Request timestamp: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, responseJSONBody)
+	})
+
+	result, _, err := client.ThreeDSecure.GetResult(serverTransID, "")
+	if err != nil {
+		t.Errorf("Error performing GetResult: %v", err)
+	}
+
+	expectedMPI := &MPI{ECI: "05", DSTransID: "c272b04f-6e7b-43a2-bb78-90f4fb94aa25", AuthenticationValue: "ODQzNjgwNjU0ZjM3N2JmYTg0NTM=", MessageVersion: "2.1.0"}
+	if !reflect.DeepEqual(result.MPI(), expectedMPI) {
+		t.Errorf("Result MPI = %v, want %v", result.MPI(), expectedMPI)
+	}
+}
+
+func TestParseMethodNotification(t *testing.T) {
+	form := url.Values{"threeDSMethodData": {"eyJ0aHJlZURTU2VydmVyVHJhbnNJRCI6ICJhZjY1YzM2OS01OWI5LTRmOGQtYjJmNi03ZDdkNWY1YzY5ZDUifQ"}}
+	request := httptest.NewRequest("POST", "/3ds2/method", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	notification, err := ParseMethodNotification(request)
+	if err != nil {
+		t.Errorf("Error parsing method notification: %v", err)
+	}
+
+	if got, want := notification.ServerTransID, serverTransID; got != want {
+		t.Errorf("Notification ServerTransID = %v, want %v", got, want)
+	}
+}
+
+func TestParseChallengeNotification(t *testing.T) {
+	form := url.Values{"cres": {"eyJ0aHJlZURTU2VydmVyVHJhbnNJRCI6ICJhZjY1YzM2OS01OWI5LTRmOGQtYjJmNi03ZDdkNWY1YzY5ZDUiLCAiYWNzVHJhbnNJRCI6ICIxM2M3MDFhMy01YTg4LTRjNDUtODllOS1lZjY1ZTUwYThiZjkiLCAiY2hhbGxlbmdlQ29tcGxldGlvbkluZCI6ICJZIiwgIm1lc3NhZ2VUeXBlIjogIkNyZXMiLCAibWVzc2FnZVZlcnNpb24iOiAiMi4xLjAiLCAidHJhbnNTdGF0dXMiOiAiWSJ9"}}
+	request := httptest.NewRequest("POST", "/3ds2/challenge", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	notification, err := ParseChallengeNotification(request)
+	if err != nil {
+		t.Errorf("Error parsing challenge notification: %v", err)
+	}
+
+	expectedNotification := &ChallengeNotification{
+		ServerTransID:          serverTransID,
+		ACSTransID:             "13c701a3-5a88-4c45-89e9-ef65e50a8bf9",
+		ChallengeCompletionInd: "Y",
+		MessageType:            "Cres",
+		MessageVersion:         "2.1.0",
+		TransStatus:            "Y",
+	}
+	if !reflect.DeepEqual(notification, expectedNotification) {
+		t.Errorf("Notification = %v, want %v", notification, expectedNotification)
+	}
+}
@@ -0,0 +1,184 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCardStorageService_VerifyEnrolled(t *testing.T) {
+	enrolledRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="realvault-3ds-verifyenrolled" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>AiCibJ5UR7utURy_slxhJw</orderid>
+							   <result>00</result>
+							   <message>Enrolled</message>
+							   <pasref>14610544313177922</pasref>
+							   <url>https://acs.example.com/pareq</url>
+							   <pareq>eJxVUttygkAM/ZUdnitZBLzQuI5WOtqpl9FO</pareq>
+							   <enrolled>Y</enrolled>
+							   <xid>7ba3b1e6e6b542489b73243aac050777</xid>
+							   <sha1hash>f365d3fbf6350414d0908d33712c3f9d771b00e9</sha1hash>
+							</response>`
+		if got, want := r.Method, "POST"; got != want {
+			t.Errorf("Request method: %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.CardStorage.VerifyEnrolled(enrolledRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	if !response.IsEnrolled() {
+		t.Errorf("Response IsEnrolled = false for enrolled %v", response.Enrolled)
+	}
+
+	if got, want := response.URL, "https://acs.example.com/pareq"; got != want {
+		t.Errorf("Response URL = %v, want %v", got, want)
+	}
+
+	acsForm := response.ACSForm("https://merchant.example.com/3ds", "AiCibJ5UR7utURy_slxhJw")
+	expectedForm := url.Values{"PaReq": {"eJxVUttygkAM/ZUdnitZBLzQuI5WOtqpl9FO"}, "TermUrl": {"https://merchant.example.com/3ds"}, "MD": {"AiCibJ5UR7utURy_slxhJw"}}
+	if !reflect.DeepEqual(acsForm, expectedForm) {
+		t.Errorf("ACSForm = %v, want %v", acsForm, expectedForm)
+	}
+}
+
+func TestParseACSResponse(t *testing.T) {
+	form := url.Values{"PaRes": {"eJxVUttuwjAM/ZWq7zRJaVNAbhAbTENCgIBpe81atzRaL6Mpg/39krYwJuXBx/axj4/D51HH6TrN2sbWo"}, "MD": {"AiCibJ5UR7utURy_slxhJw"}}
+	request := httptest.NewRequest("POST", "/3ds", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	paRes, md, err := ParseACSResponse(request)
+	if err != nil {
+		t.Errorf("Error parsing ACS response: %v", err)
+	}
+
+	if got, want := paRes, form.Get("PaRes"); got != want {
+		t.Errorf("PaRes = %v, want %v", got, want)
+	}
+
+	if got, want := md, "AiCibJ5UR7utURy_slxhJw"; got != want {
+		t.Errorf("MD = %v, want %v", got, want)
+	}
+}
+
+func TestPaymentsService_VerifySig(t *testing.T) {
+	sigRequest := &PaymentRequest{
+		Account: "internet",
+		OrderID: "AiCibJ5UR7utURy_slxhJw",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		PaRes: "eJxVUttuwjAM/ZWq7zRJaVNAbhAbTENCgIBpe81atzRaL6Mpg/39krYwJuXBx/axj4/D51HH6TrN2sbWo",
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="3ds-verifysig" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><pares>eJxVUttuwjAM/ZWq7zRJaVNAbhAbTENCgIBpe81atzRaL6Mpg/39krYwJuXBx/axj4/D51HH6TrN2sbWo</pares><amount currency="CAD">10000</amount><sha1hash>9b1687d318b226fd1954f423e95507de6f481b30</sha1hash></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>AiCibJ5UR7utURy_slxhJw</orderid>
+							   <result>00</result>
+							   <message>Authentication Successful</message>
+							   <pasref>14610544313177922</pasref>
+							   <threedsecure>
+								  <status>Y</status>
+								  <eci>5</eci>
+								  <xid>e9dafe706f7142469c45d4877aaf5984</xid>
+								  <cavv>AAACBllleHchZTBWIGV4AAAAAAA=</cavv>
+								  <algorithm>2</algorithm>
+							   </threedsecure>
+							   <sha1hash>b9b359225ce3f38068abf8eedf6974cdff68bcb9</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.Payments.VerifySig(sigRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedMPI := &MPI{CAVV: "AAACBllleHchZTBWIGV4AAAAAAA=", XID: "e9dafe706f7142469c45d4877aaf5984", ECI: "5"}
+	if !reflect.DeepEqual(response.MPI(), expectedMPI) {
+		t.Errorf("Response MPI = %v, want %v", response.MPI(), expectedMPI)
+	}
+
+	response.ThreeDSecure.Status = ThreeDSecureFailed
+	if mpi := response.MPI(); mpi != nil {
+		t.Errorf("Response MPI = %v for failed authentication, want nil", mpi)
+	}
+}
+
+func TestCardStorageService_Authorize_MPI(t *testing.T) {
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		MPI: &MPI{CAVV: "AAACBllleHchZTBWIGV4AAAAAAA=", XID: "e9dafe706f7142469c45d4877aaf5984", ECI: "5"},
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount><mpi><cavv>AAACBllleHchZTBWIGV4AAAAAAA=</cavv><xid>e9dafe706f7142469c45d4877aaf5984</xid><eci>5</eci></mpi></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	_, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+}
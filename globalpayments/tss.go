@@ -0,0 +1,77 @@
+package globalpayments
+
+import "strings"
+
+//TSS address types
+const (
+	TSSAddressBilling  = "billing"
+	TSSAddressShipping = "shipping"
+)
+
+//TSSInfo request struct for the Transaction Suitability Score. The billing address code is checked by AVS.
+type TSSInfo struct {
+	CustomerNumber    string       `xml:"custnum,omitempty"`
+	ProductID         string       `xml:"prodid,omitempty"`
+	VariableReference string       `xml:"varref,omitempty"`
+	CustomerIPAddress string       `xml:"custipaddress,omitempty"`
+	Addresses         []TSSAddress `xml:"address,omitempty"`
+}
+
+//TSSAddress request struct
+type TSSAddress struct {
+	Type    string `xml:"type,attr"`
+	Code    string `xml:"code,omitempty"`
+	Country string `xml:"country,omitempty"`
+}
+
+//TSSResult response struct for the Transaction Suitability Score and the result of each check that made it up
+type TSSResult struct {
+	Result string     `xml:"result"`
+	Checks []TSSCheck `xml:"check"`
+}
+
+//TSSCheck response struct
+type TSSCheck struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+//AVSCode returns the address in the format checked by AVS, the digits of the post code and the digits of the first line
+//separated by a pipe, e.g. "59|123" for Flat 123, W5 9HR.
+func (address *Address) AVSCode() string {
+	return onlyDigits(address.PostCode) + "|" + onlyDigits(address.Line1)
+}
+
+//onlyDigits drops everything but the digits from str
+func onlyDigits(str string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, str)
+}
+
+//SetBillingAddress sets the billing address, with the code used by AVS
+func (tss *TSSInfo) SetBillingAddress(address *Address) {
+	tss.setAddress(TSSAddressBilling, address)
+}
+
+//SetShippingAddress sets the shipping address
+func (tss *TSSInfo) SetShippingAddress(address *Address) {
+	tss.setAddress(TSSAddressShipping, address)
+}
+
+func (tss *TSSInfo) setAddress(addressType string, address *Address) {
+	tssAddress := TSSAddress{Type: addressType, Code: address.AVSCode()}
+	if address.Country != nil {
+		tssAddress.Country = address.Country.Code
+	}
+	for i := range tss.Addresses {
+		if tss.Addresses[i].Type == addressType {
+			tss.Addresses[i] = tssAddress
+			return
+		}
+	}
+	tss.Addresses = append(tss.Addresses, tssAddress)
+}
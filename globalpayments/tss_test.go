@@ -0,0 +1,76 @@
+package globalpayments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAddress_AVSCode(t *testing.T) {
+	address := &Address{Line1: "Flat 123", Line2: "House 456", PostCode: "W5 9HR"}
+	if got, want := address.AVSCode(), "59|123"; got != want {
+		t.Errorf("Address AVSCode = %v, want %v", got, want)
+	}
+}
+
+func TestCardStorageService_Authorize_TSSInfo(t *testing.T) {
+	tssInfo := &TSSInfo{CustomerNumber: "E8953893489", ProductID: "SID9838383", VariableReference: "Car Part HV", CustomerIPAddress: "123.123.123.123"}
+	tssInfo.SetBillingAddress(&Address{Line1: "Flat 123", PostCode: "W5 9HR", Country: &Country{Code: "GB"}})
+	tssInfo.SetShippingAddress(&Address{Line1: "Apartment 852", PostCode: "50001", Country: &Country{Code: "US"}})
+
+	authRequest := &CardStorageRequest{
+		Account:       "internet",
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+		Amount: &Amount{
+			Amount:   "10000",
+			Currency: "CAD",
+		},
+		TSSInfo: tssInfo,
+	}
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc(apiURLPath, func(w http.ResponseWriter, r *http.Request) {
+		requestXMLBody := `<request type="receipt-in" timestamp="20180614095000"><merchantid>realexsandbox</merchantid><account>internet</account><orderid>AiCibJ5UR7utURy_slxhJw</orderid><payerref>03e28f0e-492e-80bd-20ec318e9334</payerref><paymentmethod>3c4af936-483e-a393-f558bec2fb2a</paymentmethod><sha1hash>59a88d763f26bdcbbf4dd65d3b0aec0b1dd5f6f6</sha1hash><amount currency="CAD">10000</amount><tssinfo><custnum>E8953893489</custnum><prodid>SID9838383</prodid><varref>Car Part HV</varref><custipaddress>123.123.123.123</custipaddress><address type="billing"><code>59|123</code><country>GB</country></address><address type="shipping"><code>50001|852</code><country>US</country></address></tssinfo></request>`
+		responseXMLBody := `<response timestamp="20180731090859">
+							   <merchantid>MerchantId</merchantid>
+							   <account>internet</account>
+							   <orderid>N6qsk4kYRZihmPrTXWYS6g</orderid>
+							   <authcode>12345</authcode>
+							   <result>00</result>
+							   <message>[ test system ] AUTHORISED</message>
+							   <pasref>14610544313177922</pasref>
+							   <avspostcoderesponse>M</avspostcoderesponse>
+							   <avsaddressresponse>M</avsaddressresponse>
+							   <tss>
+								  <result>89</result>
+								  <check id="1001">9</check>
+								  <check id="1002">9</check>
+							   </tss>
+							   <sha1hash>77ac77956e57156f47142a5723835badf767e272</sha1hash>
+							</response>`
+		body, _ := ioutil.ReadAll(r.Body)
+		if !reflect.DeepEqual(string(body), requestXMLBody) {
+			t.Errorf("Request Body = %v, want %v", string(body), requestXMLBody)
+		}
+		fmt.Fprint(w, responseXMLBody)
+	})
+
+	response, _, err := client.CardStorage.Authorize(authRequest)
+	if err != nil {
+		t.Errorf("Error performing Client.Do: %v", err)
+	}
+
+	expectedTSS := &TSSResult{Result: "89", Checks: []TSSCheck{{ID: "1001", Value: "9"}, {ID: "1002", Value: "9"}}}
+	if !reflect.DeepEqual(response.TSS, expectedTSS) {
+		t.Errorf("Response TSS = %v, want %v", response.TSS, expectedTSS)
+	}
+
+	if !response.AVSFullMatch() {
+		t.Errorf("Response AVSFullMatch = false for %v %v", response.AVSPostcodeResponse, response.AVSAddressResponse)
+	}
+}
@@ -0,0 +1,343 @@
+package globalpayments
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+//Field limits and character sets enforced by Global Payments
+const (
+	MaxOrderIDLength   = 50
+	MaxReferenceLength = 50
+	MaxAccountLength   = 30
+	MaxNameLength      = 100
+	MaxPostCodeLength  = 30
+	MaxAmountLength    = 11
+)
+
+var (
+	orderIDPattern   = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+	referencePattern = regexp.MustCompile(`^[A-Za-z0-9_\-.]+$`)
+	accountPattern   = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+	postCodePattern  = regexp.MustCompile(`^[A-Za-z0-9 \-]+$`)
+	amountPattern    = regexp.MustCompile(`^[0-9]+$`)
+	currencyPattern  = regexp.MustCompile(`^[A-Z]{3}$`)
+	tssCodePattern   = regexp.MustCompile(`^[0-9]*\|[0-9]*$`)
+)
+
+//FieldErrors every field error found on a request by the checks run before it is signed. Every service method returns its
+//request checks as FieldErrors, single value checks such as Card.Validate return a *FieldError.
+type FieldErrors []*FieldError
+
+func (errs FieldErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+//fieldValidator collects the field errors of a request
+type fieldValidator struct {
+	errs FieldErrors
+}
+
+func (validator *fieldValidator) add(field string, message string) {
+	validator.errs = append(validator.errs, &FieldError{Field: field, Message: message})
+}
+
+//addErr adds an error returned by one of the single field checks, such as Card.Validate
+func (validator *fieldValidator) addErr(err error) {
+	switch err := err.(type) {
+	case nil:
+	case *FieldError:
+		validator.errs = append(validator.errs, err)
+	case FieldErrors:
+		validator.errs = append(validator.errs, err...)
+	default:
+		validator.add("", err.Error())
+	}
+}
+
+func (validator *fieldValidator) err() error {
+	if len(validator.errs) == 0 {
+		return nil
+	}
+	return validator.errs
+}
+
+func (validator *fieldValidator) required(field string, value string) bool {
+	if value == "" {
+		validator.add(field, "is required")
+		return false
+	}
+	return true
+}
+
+func (validator *fieldValidator) match(field string, value string, pattern *regexp.Regexp, maxLength int, required bool) {
+	if value == "" {
+		if required {
+			validator.add(field, "is required")
+		}
+		return
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		validator.add(field, fmt.Sprintf("must be at most %d characters", maxLength))
+	}
+	if !pattern.MatchString(value) {
+		validator.add(field, "contains characters that are not allowed")
+	}
+}
+
+func (validator *fieldValidator) orderID(value string, required bool) {
+	validator.match("orderid", value, orderIDPattern, MaxOrderIDLength, required)
+}
+
+func (validator *fieldValidator) reference(field string, value string, required bool) {
+	validator.match(field, value, referencePattern, MaxReferenceLength, required)
+}
+
+func (validator *fieldValidator) account(value string) {
+	validator.match("account", value, accountPattern, MaxAccountLength, false)
+}
+
+func (validator *fieldValidator) name(field string, value string, required bool) {
+	if value == "" {
+		if required {
+			validator.add(field, "is required")
+		}
+		return
+	}
+	if utf8.RuneCountInString(value) > MaxNameLength {
+		validator.add(field, fmt.Sprintf("must be at most %d characters", MaxNameLength))
+	}
+	if strings.IndexFunc(value, func(r rune) bool { return r < ' ' || r == 0x7f }) >= 0 {
+		validator.add(field, "contains control characters")
+	}
+}
+
+func (validator *fieldValidator) amount(amount *Amount, required bool) {
+	if amount == nil {
+		if required {
+			validator.add("amount", "is required")
+		}
+		return
+	}
+	validator.match("amount", amount.Amount, amountPattern, MaxAmountLength, true)
+	if !currencyPattern.MatchString(amount.Currency) {
+		validator.add("amount.currency", "must be a three letter ISO 4217 currency code")
+	}
+}
+
+func (validator *fieldValidator) address(field string, address *Address) {
+	if address == nil {
+		return
+	}
+	validator.match(field+".postcode", address.PostCode, postCodePattern, MaxPostCodeLength, false)
+}
+
+func (validator *fieldValidator) payer(payer *Payer) {
+	if payer == nil {
+		validator.add("payer", "is required")
+		return
+	}
+	validator.reference("payer.ref", payer.Ref, true)
+	validator.name("payer.firstname", payer.FirstName, false)
+	validator.name("payer.surname", payer.Surname, false)
+	validator.address("payer.address", payer.Address)
+}
+
+//tss checks the TSS address codes, which carry the digits of the address post codes
+func (validator *fieldValidator) tss(tss *TSSInfo) {
+	if tss == nil {
+		return
+	}
+	for _, address := range tss.Addresses {
+		validator.match("tssinfo.address.code", address.Code, tssCodePattern, MaxPostCodeLength, false)
+	}
+}
+
+//channel checks the request only uses features its channel allows. threeDSecure is set for the requests that start a
+//3D Secure authentication, which are not allowed at all on some channels.
+func (validator *fieldValidator) channel(channel Channel, mpi *MPI, storedCredential *StoredCredential, threeDSecure bool) {
+	if threeDSecure {
+		if err := validateThreeDSecureChannel(channel); err != nil {
+			validator.addErr(err)
+			return
+		}
+	}
+	validator.addErr(validateChannel(channel, mpi, storedCredential))
+}
+
+//card checks a card sent with its number. Expiry dates and numbers are checked with Card.Validate.
+func (validator *fieldValidator) card(card *Card) {
+	if card == nil {
+		validator.add("card", "is required")
+		return
+	}
+	validator.name("card.chname", card.CardHolderName, true)
+	validator.addErr(card.Validate())
+}
+
+//validate checks the fields required by the request type before the request is signed
+func (request *CardStorageRequest) validate(requestType string) error {
+	validator := &fieldValidator{}
+	validator.account(request.Account)
+	validator.supplementaryData(request.Comments, request.SupplementaryData)
+	validator.tss(request.TSSInfo)
+	switch requestType {
+	case requestTypeReceiptIn, requestTypePaymentOut, requestTypeRealvaultDCCRate, requestTypeRealvaultVerifyEnrolled:
+		validator.orderID(request.OrderID, true)
+		validator.reference("payerref", request.PayerRef, true)
+		validator.reference("paymentmethod", request.PaymentMethod, true)
+		validator.amount(request.Amount, true)
+		validator.channel(request.Channel, request.MPI, request.StoredCredential, requestType == requestTypeRealvaultVerifyEnrolled)
+		if requestType == requestTypeReceiptIn {
+			validator.addErr(validateRecurring(request.Recurring, request.StoredCredential))
+			validator.addErr(validateStoredCredential(request.StoredCredential))
+			validator.addErr(validateMPI(request.MPI, ""))
+		}
+	case requestTypeReceiptInOTB:
+		validator.orderID(request.OrderID, true)
+		validator.reference("payerref", request.PayerRef, true)
+		validator.reference("paymentmethod", request.PaymentMethod, true)
+		validator.channel(request.Channel, request.MPI, request.StoredCredential, false)
+	case requestTypePayerNew, requestTypePayerEdit:
+		validator.orderID(request.OrderID, false)
+		validator.payer(request.Payer)
+	case requestTypeCardNew:
+		validator.orderID(request.OrderID, false)
+		validator.card(request.Card)
+		if request.Card != nil {
+			validator.reference("card.ref", request.Card.Ref, true)
+			validator.reference("card.payerref", request.Card.PayerRef, true)
+		}
+	case requestTypeCardUpdate, requestTypeCardCancel:
+		validator.orderID(request.OrderID, false)
+		if request.Card == nil {
+			validator.add("card", "is required")
+			break
+		}
+		validator.reference("card.ref", request.Card.Ref, true)
+		validator.reference("card.payerref", request.Card.PayerRef, true)
+		if requestType == requestTypeCardUpdate {
+			validator.name("card.chname", request.Card.CardHolderName, false)
+			if request.Card.Number != "" {
+				validator.addErr(request.Card.Validate())
+			}
+		}
+	}
+	return validator.err()
+}
+
+//validate checks the fields required by the request type before the request is signed
+func (request *PaymentRequest) validate(requestType string) error {
+	validator := &fieldValidator{}
+	validator.account(request.Account)
+	validator.supplementaryData(request.Comments, request.SupplementaryData)
+	validator.orderID(request.OrderID, true)
+	switch requestType {
+	case requestTypeAuth, requestTypeDCCRate, requestTypeVerifyEnrolled:
+		validator.amount(request.Amount, true)
+		validator.card(request.Card)
+	case requestTypeManual:
+		validator.required("authcode", request.AuthCode)
+		validator.amount(request.Amount, true)
+		validator.card(request.Card)
+	case requestTypeOffline:
+		validator.required("pasref", request.PasRef)
+		validator.required("authcode", request.AuthCode)
+		validator.amount(request.Amount, false)
+	case requestTypeOTB:
+		validator.card(request.Card)
+	case requestTypeVerifySig:
+		validator.amount(request.Amount, true)
+		validator.required("pares", request.PaRes)
+	}
+	validator.tss(request.TSSInfo)
+	validator.channel(request.Channel, request.MPI, request.StoredCredential, requestType == requestTypeVerifyEnrolled || requestType == requestTypeVerifySig)
+	if requestType == requestTypeAuth {
+		validator.addErr(validateRecurring(request.Recurring, request.StoredCredential))
+		validator.addErr(validateStoredCredential(request.StoredCredential))
+		validator.addErr(validateMPI(request.MPI, request.getCardType()))
+	}
+	return validator.err()
+}
+
+//validate checks the fields required by the request type before the request is signed
+func (request *ScheduleRequest) validate(requestType string) error {
+	validator := &fieldValidator{}
+	validator.account(request.Account)
+	validator.comment("comment", request.Comment)
+	switch requestType {
+	case requestTypeScheduleNew:
+		validator.reference("scheduleref", request.ScheduleRef, true)
+		validator.reference("payerref", request.PayerRef, true)
+		validator.reference("paymentmethod", request.PaymentMethod, true)
+		validator.amount(request.Amount, true)
+		validator.required("schedule", string(request.Frequency))
+		validator.orderID(request.OrderIDStub, false)
+	case requestTypeScheduleGet, requestTypeScheduleDelete:
+		validator.reference("scheduleref", request.ScheduleRef, true)
+	case requestTypeScheduleSearch:
+		validator.reference("payerref", request.PayerRef, false)
+		validator.reference("paymentmethod", request.PaymentMethod, false)
+	}
+	return validator.err()
+}
+
+//validate checks the fields required by payment-set before the request is signed
+func (request *APMRequest) validate() error {
+	validator := &fieldValidator{}
+	validator.account(request.Account)
+	validator.supplementaryData(request.Comments, request.SupplementaryData)
+	validator.orderID(request.OrderID, true)
+	validator.amount(request.Amount, true)
+	validator.required("paymentmethod", request.PaymentMethod)
+	if request.PaymentMethodDetails == nil {
+		validator.add("paymentmethoddetails", "is required")
+	} else {
+		validator.required("paymentmethoddetails.ReturnURL", request.PaymentMethodDetails.ReturnURL)
+		validator.required("paymentmethoddetails.StatusUpdateURL", request.PaymentMethodDetails.StatusUpdateURL)
+	}
+	return validator.err()
+}
+
+//validate checks the fields required by auth-mobile before the request is signed
+func (request *MobileRequest) validate() error {
+	validator := &fieldValidator{}
+	validator.account(request.Account)
+	validator.supplementaryData(request.Comments, request.SupplementaryData)
+	validator.orderID(request.OrderID, true)
+	validator.required("mobile", request.Mobile)
+	validator.required("token", request.Token)
+	validator.amount(request.Amount, request.Mobile == MobileGooglePay)
+	return validator.err()
+}
+
+//validate checks the HPP fields the gateway limits, including the address post codes, before the request is signed
+func (request *HPPRequest) validate() error {
+	validator := &fieldValidator{}
+	validator.account(request.Account)
+	validator.comment("COMMENT1", request.Comment1)
+	validator.comment("COMMENT2", request.Comment2)
+	validator.match("HPP_BILLING_POSTALCODE", request.BillingPostalCode, postCodePattern, MaxPostCodeLength, false)
+	validator.match("HPP_SHIPPING_POSTALCODE", request.ShippingPostalCode, postCodePattern, MaxPostCodeLength, false)
+	return validator.err()
+}
+
+//validate checks the channel allows 3D Secure before the request is signed
+func (request *CheckVersionRequest) validate() error {
+	validator := &fieldValidator{}
+	validator.channel(request.Channel, nil, nil, true)
+	return validator.err()
+}
+
+//validate checks the channel allows 3D Secure before the request is signed
+func (request *AuthenticationRequest) validate() error {
+	validator := &fieldValidator{}
+	validator.channel(request.Channel, nil, nil, true)
+	return validator.err()
+}
@@ -0,0 +1,90 @@
+package globalpayments
+
+import (
+	"strings"
+	"testing"
+)
+
+func fieldErrorFields(err error) []string {
+	fieldErrors, _ := err.(FieldErrors)
+	fields := make([]string, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		fields[i] = fieldError.Field
+	}
+	return fields
+}
+
+func TestCardStorageService_Authorize_MissingAmount(t *testing.T) {
+	client, _ := NewClient()
+
+	authRequest := &CardStorageRequest{
+		OrderID:       "AiCibJ5UR7utURy_slxhJw",
+		PayerRef:      "03e28f0e-492e-80bd-20ec318e9334",
+		PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a",
+	}
+	_, _, err := client.CardStorage.Authorize(authRequest)
+	if got, want := strings.Join(fieldErrorFields(err), ","), "amount"; got != want {
+		t.Errorf("Authorize error fields = %v, want %v", got, want)
+	}
+}
+
+func TestCardStorageRequest_Validate(t *testing.T) {
+	fixNow(t)
+	tests := []struct {
+		requestType string
+		request     *CardStorageRequest
+		fields      string
+	}{
+		{"receipt-in", &CardStorageRequest{}, "orderid,payerref,paymentmethod,amount"},
+		{"receipt-in", &CardStorageRequest{OrderID: "order#1", PayerRef: strings.Repeat("a", 51), PaymentMethod: "card 1", Amount: &Amount{Amount: "10.00", Currency: "eur"}}, "orderid,payerref,paymentmethod,amount,amount.currency"},
+		{"receipt-in", &CardStorageRequest{OrderID: "AiCibJ5UR7utURy_slxhJw", PayerRef: "03e28f0e-492e-80bd-20ec318e9334", PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a", Amount: &Amount{Amount: "10000", Currency: "CAD"}, StoredCredential: MerchantInitiatedStoredCredential(StoredCredentialRecurring, "")}, "storedcredential.srd"},
+		{"receipt-in-otb", &CardStorageRequest{Account: "internet", OrderID: "AiCibJ5UR7utURy_slxhJw", PayerRef: "03e28f0e-492e-80bd-20ec318e9334", PaymentMethod: "3c4af936-483e-a393-f558bec2fb2a"}, ""},
+		{"payer-new", &CardStorageRequest{Payer: &Payer{Ref: "03e28f0e-492e-80bd-20ec318e9334", FirstName: strings.Repeat("a", 101), Address: &Address{PostCode: "W5 9HR!"}}}, "payer.firstname,payer.address.postcode"},
+		{"payer-edit", &CardStorageRequest{}, "payer"},
+		{"card-new", &CardStorageRequest{Card: &Card{Number: "4263970000005262", ExpDate: "0525", CardHolderName: "James\nMason"}}, "card.chname,card.ref,card.payerref"},
+		{"card-cancel-card", &CardStorageRequest{Card: &Card{Ref: "3c4af936-483e-a393-f558bec2fb2a"}}, "card.payerref"},
+	}
+
+	for _, test := range tests {
+		err := test.request.validate(test.requestType)
+		if got := strings.Join(fieldErrorFields(err), ","); got != test.fields {
+			t.Errorf("%v validate error fields = %v, want %v", test.requestType, got, test.fields)
+		}
+	}
+}
+
+func TestPaymentRequest_Validate(t *testing.T) {
+	fixNow(t)
+	tests := []struct {
+		requestType string
+		request     *PaymentRequest
+		fields      string
+	}{
+		{"auth", &PaymentRequest{}, "orderid,amount,card"},
+		{"auth", &PaymentRequest{Channel: ChannelMOTO, MPI: &MPI{ECI: "5"}, Recurring: &Recurring{Type: RecurringFixed, Sequence: RecurringFirst, Flag: "1"}, StoredCredential: FirstStoredCredential(StoredCredentialOneOff)}, "orderid,amount,card,mpi,recurring,mpi.cavv"},
+		{"otb", &PaymentRequest{OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3", Card: &Card{Number: "4263970000005262", ExpDate: "0525", CardHolderName: "James Mason"}, TSSInfo: &TSSInfo{Addresses: []TSSAddress{{Type: TSSAddressBilling, Code: "W59HR|123"}}}}, "tssinfo.address.code"},
+		{"offline", &PaymentRequest{OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3"}, "pasref,authcode"},
+		{"3ds-verifysig", &PaymentRequest{OrderID: "3be87fe9-db71-4f9c-5cd6-c8e9b38d2fc3", Amount: &Amount{Amount: "1001", Currency: "EUR"}}, "pares"},
+	}
+
+	for _, test := range tests {
+		err := test.request.validate(test.requestType)
+		if got := strings.Join(fieldErrorFields(err), ","); got != test.fields {
+			t.Errorf("%v validate error fields = %v, want %v", test.requestType, got, test.fields)
+		}
+	}
+}
+
+func TestHPPRequest_Validate(t *testing.T) {
+	request := &HPPRequest{BillingPostalCode: "W5 9HR", ShippingPostalCode: "W5 9HR!"}
+	if got, want := strings.Join(fieldErrorFields(request.validate()), ","), "HPP_SHIPPING_POSTALCODE"; got != want {
+		t.Errorf("HPP validate error fields = %v, want %v", got, want)
+	}
+}
+
+func TestFieldErrors_Error(t *testing.T) {
+	err := FieldErrors{{Field: "orderid", Message: "is required"}, {Field: "amount", Message: "is required"}}
+	if got, want := err.Error(), "Field Error: field: orderid, is required; Field Error: field: amount, is required"; got != want {
+		t.Errorf("FieldErrors Error = %v, want %v", got, want)
+	}
+}